@@ -0,0 +1,294 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// AccessLogOption configures the behaviour of the access log interceptors.
+type AccessLogOption func(*accessLogOptions)
+
+// accessLogOptions holds the settings supplied to the access log interceptors.
+type accessLogOptions struct {
+	levels      map[codes.Code]zapcore.Level   // Per status code level overrides
+	levelFunc   func(codes.Code) zapcore.Level // Status code to level mapping
+	skipMethods []string                       // Full method or service names not to log
+	logger      *zap.Logger                    // Logger to write the access log to (default: logger.Ctx)
+}
+
+// WithAccessLogLevel logs calls finishing with the given status code at the given level.
+func WithAccessLogLevel(code codes.Code, lvl zapcore.Level) AccessLogOption {
+	return func(o *accessLogOptions) {
+		o.levels[code] = lvl
+	}
+}
+
+// WithAccessLogLevelFunc replaces the default status code to level mapping (see DefaultAccessLogLevel).
+func WithAccessLogLevelFunc(levelFunc func(codes.Code) zapcore.Level) AccessLogOption {
+	return func(o *accessLogOptions) {
+		if levelFunc != nil {
+			o.levelFunc = levelFunc
+		}
+	}
+}
+
+// WithAccessLogSkipMethods disables the access log for the given full method (i.e. /grpc.health.v1.Health/Check)
+// or service (i.e. grpc.health.v1.Health) names.
+func WithAccessLogSkipMethods(methods ...string) AccessLogOption {
+	return func(o *accessLogOptions) {
+		for _, m := range methods {
+			o.skipMethods = append(o.skipMethods, strings.TrimPrefix(m, "/"))
+		}
+	}
+}
+
+// WithAccessLogLogger writes the access log to the given logger (i.e. one routed to a dedicated file),
+// rather than the global context logger. The context logging fields are still added.
+func WithAccessLogLogger(l *zap.Logger) AccessLogOption {
+	return func(o *accessLogOptions) {
+		o.logger = l
+	}
+}
+
+// DefaultAccessLogLevel maps a status code to the level its access log is written at:
+// client errors are logged at info, server/transient problems at warn and internal errors at error.
+func DefaultAccessLogLevel(code codes.Code) zapcore.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return zapcore.InfoLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return zapcore.WarnLevel
+	case codes.Unknown, codes.Unimplemented, codes.Internal, codes.DataLoss:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// newAccessLogOptions applies the supplied options on top of the defaults.
+func newAccessLogOptions(opts []AccessLogOption) *accessLogOptions {
+	o := &accessLogOptions{
+		levels:    make(map[codes.Code]zapcore.Level),
+		levelFunc: DefaultAccessLogLevel,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// AccessLogUnaryServerInterceptor writes one structured access log record per unary call.
+// It should be chained after ContextPropagationUnaryServerInterceptor, to include the request & trace IDs.
+func AccessLogUnaryServerInterceptor(opts ...AccessLogOption) grpc.UnaryServerInterceptor {
+	o := newAccessLogOptions(opts)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if o.skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		fields := []zap.Field{messageSize("grpc.request.size", req)}
+		if err == nil {
+			fields = append(fields, messageSize("grpc.response.size", resp))
+		}
+		o.log(ctx, "Unary call finished", info.FullMethod, time.Since(start), err, fields...)
+		return resp, err
+	}
+}
+
+// AccessLogStreamServerInterceptor writes one structured access log record per stream, once it closes.
+// It should be chained after ContextPropagationStreamServerInterceptor, to include the request & trace IDs.
+func AccessLogStreamServerInterceptor(opts ...AccessLogOption) grpc.StreamServerInterceptor {
+	o := newAccessLogOptions(opts)
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if o.skip(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		start := time.Now()
+		wrapped := &accessLogStream{ServerStream: stream}
+		err := handler(srv, wrapped)
+		o.log(stream.Context(), "Stream call finished", info.FullMethod, time.Since(start), err,
+			zap.Int64("grpc.request.size", wrapped.received.Load()),
+			zap.Int64("grpc.response.size", wrapped.sent.Load()),
+			zap.Int64("stream.msgs_received", wrapped.msgsReceived.Load()),
+			zap.Int64("stream.msgs_sent", wrapped.msgsSent.Load()),
+		)
+		return err
+	}
+}
+
+// skip reports whether the access log is disabled for the given method.
+func (o *accessLogOptions) skip(fullMethod string) bool {
+	if len(o.skipMethods) == 0 {
+		return false
+	}
+	service, _ := splitFullMethod(fullMethod)
+	return slices.Contains(o.skipMethods, strings.TrimPrefix(fullMethod, "/")) || slices.Contains(o.skipMethods, service)
+}
+
+// level returns the level to log a call finishing with the given status code at.
+func (o *accessLogOptions) level(code codes.Code) zapcore.Level {
+	if lvl, ok := o.levels[code]; ok {
+		return lvl
+	}
+	return o.levelFunc(code)
+}
+
+// log writes the access log record for a finished call.
+func (o *accessLogOptions) log(ctx context.Context, msg, fullMethod string, duration time.Duration, err error, extra ...zap.Field) {
+	code := status.Code(err)
+	var l *zap.Logger
+	if o.logger != nil {
+		l = o.logger.With(logger.Fields(ctx)...)
+	} else {
+		l = logger.Ctx(ctx)
+	}
+	ce := l.Check(o.level(code), msg)
+	if ce == nil {
+		return
+	}
+	service, method := splitFullMethod(fullMethod)
+	fields := []zap.Field{
+		zap.String("grpc.service", service),
+		zap.String("grpc.method", method),
+		zap.String("grpc.code", code.String()),
+		zap.Duration("grpc.duration", duration),
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, zap.String("peer.address", p.Addr.String()))
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ua := md.Get("user-agent"); len(ua) > 0 {
+		fields = append(fields, zap.String("user_agent", ua[0]))
+	}
+	if !hasField(logger.Fields(ctx), ReqLogKey) { // Not chained after the propagation interceptor, so use the incoming ID
+		fields = append(fields, incomingRequestIDField(md)...)
+	}
+	fields = append(fields, extra...)
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	ce.Write(fields...)
+}
+
+// accessLogStream counts the messages (and bytes) sent & received on a stream.
+type accessLogStream struct {
+	grpc.ServerStream
+	msgsSent     atomic.Int64
+	msgsReceived atomic.Int64
+	sent         atomic.Int64
+	received     atomic.Int64
+}
+
+// SendMsg sends a message on the stream, counting it if successful.
+func (s *accessLogStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.msgsSent.Add(1)
+		s.sent.Add(int64(protoSize(m)))
+	}
+	return err
+}
+
+// RecvMsg receives a message from the stream, counting it if successful.
+func (s *accessLogStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.msgsReceived.Add(1)
+		s.received.Add(int64(protoSize(m)))
+	}
+	return err
+}
+
+// incomingRequestIDField returns the logging field for the (unvalidated) incoming Request ID, if there is one.
+// Invalid IDs (see DefaultRequestIDValidation) are only logged sanitised, as the client's ID.
+func incomingRequestIDField(md metadata.MD) []zap.Field {
+	reqID := firstValue(md, RequestIDKey)
+	if len(reqID) == 0 {
+		return nil
+	}
+	validation := DefaultRequestIDValidation()
+	if reason := validation.Validate(reqID); len(reason) > 0 {
+		return []zap.Field{zap.String(ClientReqLogKey, SanitiseValue(reqID))}
+	}
+	return []zap.Field{zap.String(ReqLogKey, reqID)}
+}
+
+// splitFullMethod splits a full gRPC method name (/package.Service/Method) into its service & method names.
+func splitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+	return "unknown", fullMethod
+}
+
+// protoSize returns the encoded size of a protobuf message, or zero for any other type.
+func protoSize(m interface{}) int {
+	if msg, ok := m.(proto.Message); ok {
+		return proto.Size(msg)
+	}
+	return 0
+}
+
+// messageSize returns a field with the encoded size of a protobuf message, or a skipped field for any other type.
+func messageSize(key string, m interface{}) zap.Field {
+	if msg, ok := m.(proto.Message); ok {
+		return zap.Int(key, proto.Size(msg))
+	}
+	return zap.Skip()
+}
+
+// hasField reports whether the fields include the given key.
+func hasField(fields []zap.Field, key string) bool {
+	return slices.ContainsFunc(fields, func(f zap.Field) bool { return f.Key == key })
+}
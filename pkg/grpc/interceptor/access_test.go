@@ -0,0 +1,195 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// accessLogCall runs a unary call through the context propagation & access log interceptors.
+func accessLogCall(ctx context.Context, method string, handlerErr error, opts ...AccessLogOption) {
+	access := AccessLogUnaryServerInterceptor(opts...)
+	chain := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return access(ctx, req, info, handler)
+	}
+	_, _ = ContextPropagationUnaryServerInterceptor()(ctx, wrapperspb.String("request"), &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return chain(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, interface{}) (interface{}, error) {
+				if handlerErr != nil {
+					return nil, handlerErr
+				}
+				return wrapperspb.String("a longer response"), nil
+			})
+		})
+}
+
+func TestAccessLogUnary(t *testing.T) {
+	logs := observeLogs(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "access-id", "user-agent", "test-client/1.0"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 1, 2, 3), Port: 4321}})
+	accessLogCall(ctx, "/pkg.Service/Method", nil)
+
+	entries := logs.FilterMessage("Unary call finished").All()
+	if !assert.Len(t, entries, 1) {
+		return
+	}
+	assert.Equal(t, zapcore.InfoLevel, entries[0].Level)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "pkg.Service", fields["grpc.service"])
+	assert.Equal(t, "Method", fields["grpc.method"])
+	assert.Equal(t, "OK", fields["grpc.code"])
+	assert.Equal(t, "10.1.2.3:4321", fields["peer.address"])
+	assert.Equal(t, "test-client/1.0", fields["user_agent"])
+	assert.Equal(t, "access-id", fields[ReqLogKey])
+	assert.Equal(t, int64(proto.Size(wrapperspb.String("request"))), fields["grpc.request.size"])
+	assert.Equal(t, int64(proto.Size(wrapperspb.String("a longer response"))), fields["grpc.response.size"])
+	assert.Contains(t, fields, "grpc.duration")
+}
+
+func TestAccessLogUnaryLevels(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		opts  []AccessLogOption
+		level zapcore.Level
+	}{
+		{name: "not found", err: status.Error(codes.NotFound, "missing"), level: zapcore.InfoLevel},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "down"), level: zapcore.WarnLevel},
+		{name: "internal", err: status.Error(codes.Internal, "boom"), level: zapcore.ErrorLevel},
+		{name: "plain error", err: errors.New("boom"), level: zapcore.ErrorLevel},
+		{name: "code override", err: status.Error(codes.NotFound, "missing"),
+			opts: []AccessLogOption{WithAccessLogLevel(codes.NotFound, zapcore.DebugLevel)}, level: zapcore.DebugLevel},
+		{name: "level func", opts: []AccessLogOption{WithAccessLogLevelFunc(func(codes.Code) zapcore.Level { return zapcore.WarnLevel })},
+			level: zapcore.WarnLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs := observeLogs(t)
+			accessLogCall(context.Background(), "/pkg.Service/Method", tt.err, tt.opts...)
+			entries := logs.FilterMessage("Unary call finished").All()
+			if assert.Len(t, entries, 1) {
+				assert.Equal(t, tt.level, entries[0].Level)
+				assert.Equal(t, status.Code(tt.err).String(), entries[0].ContextMap()["grpc.code"])
+				if tt.err != nil {
+					assert.NotContains(t, entries[0].ContextMap(), "grpc.response.size")
+				}
+			}
+		})
+	}
+}
+
+func TestAccessLogSkipMethods(t *testing.T) {
+	logs := observeLogs(t)
+	opts := []AccessLogOption{WithAccessLogSkipMethods("/grpc.health.v1.Health/Check", "pkg.Internal")}
+	accessLogCall(context.Background(), "/grpc.health.v1.Health/Check", nil, opts...)
+	accessLogCall(context.Background(), "/pkg.Internal/Anything", nil, opts...)
+	assert.Equal(t, 0, logs.FilterMessage("Unary call finished").Len())
+	accessLogCall(context.Background(), "/grpc.health.v1.Health/Watch", nil, opts...)
+	assert.Equal(t, 1, logs.FilterMessage("Unary call finished").Len())
+}
+
+func TestAccessLogWithoutPropagation(t *testing.T) {
+	logs := observeLogs(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "incoming-id"))
+	_, err := AccessLogUnaryServerInterceptor()(ctx, "not a proto", &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"},
+		func(context.Context, interface{}) (interface{}, error) { return "response", nil })
+	assert.NoError(t, err)
+	entries := logs.FilterMessage("Unary call finished").All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "incoming-id", fields[ReqLogKey])
+		assert.NotContains(t, fields, "grpc.request.size")
+	}
+
+	logs.TakeAll()
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "spoofed\n{\"level\":\"error\"}"))
+	_, err = AccessLogUnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Method"},
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	assert.NoError(t, err)
+	entries = logs.FilterMessage("Unary call finished").All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.NotContains(t, fields, ReqLogKey, "an invalid incoming ID should not be logged as the Request ID")
+		assert.Equal(t, `spoofed?{"level":"error"}`, fields[ClientReqLogKey])
+	}
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	service, method := splitFullMethod("/grpc.health.v1.Health/Check")
+	assert.Equal(t, "grpc.health.v1.Health", service)
+	assert.Equal(t, "Check", method)
+	service, method = splitFullMethod("bad")
+	assert.Equal(t, "unknown", service)
+	assert.Equal(t, "bad", method)
+}
+
+func TestAccessLogStreamBufconn(t *testing.T) {
+	logs := observeLogs(t)
+	var seen echoStreamSeen
+	conn := newEchoServer(t, &seen, AccessLogStreamServerInterceptor())
+	ctx := ContextWithRequestID(context.Background(), "stream-access-id")
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, "/test.Echo/Echo")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var size int64
+	for _, value := range []string{"one", "two"} {
+		size += int64(proto.Size(wrapperspb.String(value)))
+		assert.NoError(t, stream.SendMsg(wrapperspb.String(value)))
+	}
+	assert.NoError(t, stream.CloseSend())
+	for {
+		if err = stream.RecvMsg(&wrapperspb.StringValue{}); err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			break
+		}
+	}
+	entries := logs.FilterMessage("Stream call finished").All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "test.Echo", fields["grpc.service"])
+		assert.Equal(t, "Echo", fields["grpc.method"])
+		assert.Equal(t, "OK", fields["grpc.code"])
+		assert.Equal(t, "stream-access-id", fields[ReqLogKey])
+		assert.Equal(t, size, fields["grpc.request.size"])
+		assert.Equal(t, size, fields["grpc.response.size"])
+		assert.Equal(t, int64(2), fields["stream.msgs_received"])
+		assert.Contains(t, fields, "peer.address")
+		assert.Contains(t, fields["user_agent"], "grpc-go")
+	}
+}
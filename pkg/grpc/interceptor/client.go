@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ContextWithRequestID returns a copy of the context carrying the given Request ID (see RequestIDFromContext),
+// also adding it to the logging fields. This is useful for calls starting from a non-gRPC entry point (i.e. a CLI or cron job).
+func ContextWithRequestID(ctx context.Context, reqID string) context.Context {
+	ctx = logger.WithFields(ctx, zap.String(ReqLogKey, reqID))
+	return context.WithValue(ctx, requestIDKey{}, reqID)
+}
+
+// ContextPropagationUnaryClientInterceptor makes sure every outgoing unary request has a Request ID.
+// The ID is taken from the outgoing metadata, the context (RequestIDFromContext) or generated, in that order.
+// Any mismatching Response ID returned in the trailer is logged.
+func ContextPropagationUnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	o := newOptions(opts)
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, reqID := o.setOutgoingRequestID(ctx)
+		if o.placement == ResponseIDNone {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		var header, trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header), grpc.Trailer(&trailer))...)
+		o.checkResponseID(ctx, method, reqID, header, trailer)
+		return err
+	}
+}
+
+// ContextPropagationStreamClientInterceptor makes sure every outgoing stream request has a Request ID.
+// The ID is taken from the outgoing metadata, the context (RequestIDFromContext) or generated, in that order.
+// Any mismatching Response ID returned in the trailer is logged, once the stream has finished.
+func ContextPropagationStreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	o := newOptions(opts)
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		ctx, reqID := o.setOutgoingRequestID(ctx)
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil || o.placement == ResponseIDNone {
+			return stream, err
+		}
+		return &clientStreamWithResponseID{ClientStream: stream, ctx: ctx, method: method, reqID: reqID, serverStreams: desc.ServerStreams, opts: o}, nil
+	}
+}
+
+// clientStreamWithResponseID checks the Response ID in the trailer, once the stream has finished.
+type clientStreamWithResponseID struct {
+	grpc.ClientStream
+	ctx           context.Context
+	method        string
+	reqID         string
+	serverStreams bool // Whether the server streams its responses (otherwise the stream finishes with the first message)
+	opts          *options
+	once          sync.Once
+}
+
+// RecvMsg receives a message from the stream, checking the trailer once the stream has finished (or failed).
+// Client streaming calls (i.e. CloseAndRecv) have finished once their single response has been received.
+func (s *clientStreamWithResponseID) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil || !s.serverStreams {
+		s.once.Do(func() {
+			var header metadata.MD
+			if s.opts.responseInHeader() {
+				header, _ = s.Header() // The stream has finished, so this won't block
+			}
+			s.opts.checkResponseID(s.ctx, s.method, s.reqID, header, s.Trailer())
+		})
+	}
+	return err
+}
+
+// setOutgoingRequestID makes sure the outgoing metadata contains a Request ID, returning the updated context & ID.
+func (o *options) setOutgoingRequestID(ctx context.Context) (context.Context, string) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if ids := md.Get(o.requestHeader()); len(ids) > 0 {
+		if reqID := strings.TrimSpace(ids[0]); len(reqID) > 0 {
+			return ctx, reqID // Already being propagated (i.e. by the server interceptor)
+		}
+	}
+	reqID := RequestIDFromContext(ctx)
+	if len(reqID) == 0 { // No Request ID, create one
+		reqID = o.newRequestID()
+		logger.Ctx(ctx).Debug("Creating outgoing Request ID", zap.String(o.reqLogKey, reqID))
+	}
+	md = md.Copy()
+	md.Set(o.requestHeader(), reqID)
+	return metadata.NewOutgoingContext(ctx, md), reqID
+}
+
+// checkResponseID logs any mismatch between the Request ID and the Response ID returned in the trailer (and/or header).
+func (o *options) checkResponseID(ctx context.Context, method, reqID string, header, trailer metadata.MD) {
+	var ids []string
+	if o.responseInTrailer() {
+		ids = trailer.Get(o.responseHeader)
+	}
+	if len(ids) == 0 && o.responseInHeader() {
+		ids = header.Get(o.responseHeader)
+	}
+	if len(ids) == 0 {
+		logger.Ctx(ctx).Debug("No Response ID returned", zap.String(FullMethodLogKey, method))
+		return
+	}
+	if respID := strings.TrimSpace(ids[0]); respID != reqID {
+		logger.Ctx(ctx).Warn("Response ID does not match the Request ID",
+			zap.String(FullMethodLogKey, method), zap.String("request_id", reqID), zap.String("response_id", respID))
+	}
+}
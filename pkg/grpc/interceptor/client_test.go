@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// newTrailerInvoker returns a unary invoker which records the outgoing Request ID and returns the given trailer.
+func newTrailerInvoker(gotID *string, trailer metadata.MD) grpc.UnaryInvoker {
+	return func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if ids := md.Get(RequestIDKey); len(ids) > 0 {
+			*gotID = ids[0]
+		}
+		for _, opt := range opts {
+			if t, ok := opt.(grpc.TrailerCallOption); ok {
+				*t.TrailerAddr = trailer
+			}
+		}
+		return nil
+	}
+}
+
+// observeLogs replaces the global logger with an observer for the duration of the test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zap.DebugLevel)
+	logger.Set(zap.New(core))
+	t.Cleanup(func() { logger.Set(nil) })
+	return logs
+}
+
+func TestUnaryClientGeneratesRequestID(t *testing.T) {
+	logs := observeLogs(t)
+	var gotID string
+	interceptor := ContextPropagationUnaryClientInterceptor()
+	err := interceptor(context.Background(), "/test.Service/Method", nil, nil, nil, newTrailerInvoker(&gotID, nil))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, gotID, "a Request ID should be generated")
+	assert.Equal(t, 1, logs.FilterMessage("No Response ID returned").Len())
+}
+
+func TestUnaryClientRequestIDFromContext(t *testing.T) {
+	logs := observeLogs(t)
+	var gotID string
+	ctx := ContextWithRequestID(context.Background(), "cron-1234")
+	assert.Equal(t, "cron-1234", RequestIDFromContext(ctx))
+	trailer := metadata.Pairs(ResponseIDKey, "cron-1234")
+	err := ContextPropagationUnaryClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil, newTrailerInvoker(&gotID, trailer))
+	assert.NoError(t, err)
+	assert.Equal(t, "cron-1234", gotID)
+	assert.Equal(t, 0, logs.FilterMessage("Response ID does not match the Request ID").Len())
+}
+
+func TestUnaryClientExistingOutgoingID(t *testing.T) {
+	observeLogs(t)
+	var gotID string
+	ctx := ContextWithRequestID(context.Background(), "context-id")
+	ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, "outgoing-id")
+	err := ContextPropagationUnaryClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil, newTrailerInvoker(&gotID, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "outgoing-id", gotID, "the existing outgoing Request ID should be kept")
+}
+
+func TestUnaryClientResponseIDMismatch(t *testing.T) {
+	logs := observeLogs(t)
+	var gotID string
+	ctx := ContextWithRequestID(context.Background(), "abcd")
+	trailer := metadata.Pairs(ResponseIDKey, "efgh")
+	err := ContextPropagationUnaryClientInterceptor()(ctx, "/test.Service/Method", nil, nil, nil, newTrailerInvoker(&gotID, trailer))
+	assert.NoError(t, err)
+	mismatches := logs.FilterMessage("Response ID does not match the Request ID").All()
+	if assert.Len(t, mismatches, 1) {
+		fields := mismatches[0].ContextMap()
+		assert.Equal(t, "abcd", fields["request_id"])
+		assert.Equal(t, "efgh", fields["response_id"])
+		assert.Equal(t, "abcd", fields[ReqLogKey], "context fields should be logged")
+	}
+}
+
+// testClientStream is a client stream which finishes after the first receive, returning the given trailer.
+type testClientStream struct {
+	grpc.ClientStream
+	trailer metadata.MD
+}
+
+func (s *testClientStream) RecvMsg(interface{}) error { return io.EOF }
+func (s *testClientStream) Trailer() metadata.MD      { return s.trailer }
+
+func TestStreamClientResponseID(t *testing.T) {
+	logs := observeLogs(t)
+	var gotID string
+	streamer := func(ctx context.Context, _ *grpc.StreamDesc, _ *grpc.ClientConn, _ string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		gotID = md.Get(RequestIDKey)[0]
+		return &testClientStream{trailer: metadata.Pairs(ResponseIDKey, "other")}, nil
+	}
+	ctx := ContextWithRequestID(context.Background(), "stream-id")
+	stream, err := ContextPropagationStreamClientInterceptor()(ctx, &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "stream-id", gotID)
+	assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+	assert.ErrorIs(t, stream.RecvMsg(nil), io.EOF)
+	assert.Equal(t, 1, logs.FilterMessage("Response ID does not match the Request ID").Len(), "the trailer should only be checked once")
+}
+
+func TestStreamClientError(t *testing.T) {
+	observeLogs(t)
+	streamer := func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	stream, err := ContextPropagationStreamClientInterceptor()(context.Background(), &grpc.StreamDesc{}, nil, "/test.Service/Stream", streamer)
+	assert.Nil(t, stream)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"                               // ULID (Crockford base32) alphabet
+	base62Alphabet    = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz" // KSUID (base62) alphabet
+	ksuidEpoch        = 1400000000                                                       // KSUID epoch (seconds)
+	ksuidLength       = 27                                                               // Encoded KSUID length
+	snowflakeEpoch    = 1288834974657                                                    // Snowflake epoch (Twitter, milliseconds)
+	snowflakeNodeBits = 10                                                               // Snowflake node ID bits
+	snowflakeSeqBits  = 12                                                               // Snowflake sequence bits
+)
+
+// IDGenerator creates new Request IDs.
+type IDGenerator interface {
+	NewID() string
+}
+
+// IDGeneratorFunc is a function which creates new Request IDs (i.e. a custom hook).
+type IDGeneratorFunc func() string
+
+// NewID returns a new ID.
+func (f IDGeneratorFunc) NewID() string {
+	return f()
+}
+
+// UUIDv4Generator returns a generator of random (version 4) UUIDs. This is the default.
+func UUIDv4Generator() IDGenerator {
+	return IDGeneratorFunc(func() string {
+		return uuid.New().String()
+	})
+}
+
+// UUIDv7Generator returns a generator of time-ordered (version 7) UUIDs.
+func UUIDv7Generator() IDGenerator {
+	return IDGeneratorFunc(func() string {
+		return uuid.Must(uuid.NewV7()).String()
+	})
+}
+
+// ULIDGenerator returns a generator of ULIDs (26 character, time-ordered, Crockford base32 IDs).
+// IDs generated within the same millisecond are monotonically increasing.
+func ULIDGenerator() IDGenerator {
+	return &ulidGenerator{}
+}
+
+// KSUIDGenerator returns a generator of KSUIDs (27 character, time-ordered, base62 IDs with second precision).
+func KSUIDGenerator() IDGenerator {
+	return IDGeneratorFunc(func() string {
+		var id [20]byte
+		binary.BigEndian.PutUint32(id[:4], uint32(time.Now().Unix()-ksuidEpoch))
+		_, _ = rand.Read(id[4:])
+		return encodeBase62(id[:], ksuidLength)
+	})
+}
+
+// SnowflakeGenerator returns a generator of Snowflake IDs (time-ordered 63 bit integers) for the given node (0-1023).
+// Each instance (i.e. service replica) should use its own node ID.
+func SnowflakeGenerator(node int64) IDGenerator {
+	return &snowflakeGenerator{node: node & (1<<snowflakeNodeBits - 1)}
+}
+
+// ulidGenerator generates monotonic ULIDs.
+type ulidGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	lastRnd [10]byte
+}
+
+// NewID returns a new ULID.
+func (g *ulidGenerator) NewID() string {
+	ms := uint64(time.Now().UnixMilli())
+	g.mu.Lock()
+	if ms <= g.lastMs && incrementBytes(g.lastRnd[:]) { // Same (or earlier) millisecond, so increment the random part
+		ms = g.lastMs
+	} else {
+		_, _ = rand.Read(g.lastRnd[:])
+	}
+	g.lastMs = ms
+	var id [16]byte
+	binary.BigEndian.PutUint16(id[:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(id[2:6], uint32(ms))
+	copy(id[6:], g.lastRnd[:])
+	g.mu.Unlock()
+	return encodeCrockford(id)
+}
+
+// snowflakeGenerator generates Snowflake IDs: 41 bits of milliseconds, 10 bits of node ID and a 12 bit sequence.
+type snowflakeGenerator struct {
+	mu     sync.Mutex
+	node   int64
+	lastMs int64
+	seq    int64
+}
+
+// NewID returns a new Snowflake ID.
+func (g *snowflakeGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ms := time.Now().UnixMilli() - snowflakeEpoch
+	if ms < g.lastMs {
+		ms = g.lastMs // Don't go backwards if the clock does
+	}
+	if ms == g.lastMs {
+		g.seq = (g.seq + 1) & (1<<snowflakeSeqBits - 1)
+		if g.seq == 0 { // Sequence exhausted, wait for the next millisecond
+			for ms <= g.lastMs {
+				time.Sleep(100 * time.Microsecond)
+				ms = time.Now().UnixMilli() - snowflakeEpoch
+			}
+		}
+	} else {
+		g.seq = 0
+	}
+	g.lastMs = ms
+	return strconv.FormatInt(ms<<(snowflakeNodeBits+snowflakeSeqBits)|g.node<<snowflakeSeqBits|g.seq, 10)
+}
+
+// incrementBytes increments a big endian number, reporting false if it overflowed.
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeCrockford encodes a 128 bit ID as 26 Crockford base32 characters (the first carrying the top 3 bits).
+func encodeCrockford(id [16]byte) string {
+	out := make([]byte, 26)
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
+
+// encodeBase62 encodes a big endian number in base62, left padded with zeros to the given length.
+func encodeBase62(src []byte, length int) string {
+	num := make([]byte, len(src))
+	copy(num, src)
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		var rem uint32
+		for j := range num { // Long division of the number by 62
+			acc := rem<<8 | uint32(num[j])
+			num[j] = byte(acc / 62)
+			rem = acc % 62
+		}
+		out[i] = base62Alphabet[rem]
+	}
+	return string(out)
+}
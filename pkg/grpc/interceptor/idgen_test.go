@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIDGeneratorFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     IDGenerator
+		pattern string
+	}{
+		{name: "uuidv4", gen: UUIDv4Generator(), pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{name: "uuidv7", gen: UUIDv7Generator(), pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`},
+		{name: "ulid", gen: ULIDGenerator(), pattern: `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`},
+		{name: "ksuid", gen: KSUIDGenerator(), pattern: `^[0-9A-Za-z]{27}$`},
+		{name: "snowflake", gen: SnowflakeGenerator(5), pattern: `^[0-9]{1,19}$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re := regexp.MustCompile(tt.pattern)
+			seen := make(map[string]bool)
+			for range 1000 {
+				id := tt.gen.NewID()
+				assert.Regexp(t, re, id)
+				assert.False(t, seen[id], "duplicate ID: %v", id)
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestIDGeneratorsSortByTime(t *testing.T) {
+	for name, gen := range map[string]IDGenerator{"uuidv7": UUIDv7Generator(), "ulid": ULIDGenerator()} {
+		var ids []string
+		for range 2000 {
+			ids = append(ids, gen.NewID())
+		}
+		assert.True(t, slices.IsSorted(ids), "%v IDs should sort in creation order", name)
+	}
+	gen := SnowflakeGenerator(1)
+	var last int64
+	for range 5000 { // More than the per millisecond sequence
+		id, err := strconv.ParseInt(gen.NewID(), 10, 64)
+		assert.NoError(t, err)
+		assert.Greater(t, id, last, "snowflake IDs should increase")
+		last = id
+	}
+	first := KSUIDGenerator().NewID()
+	time.Sleep(1100 * time.Millisecond) // KSUIDs have second precision
+	assert.Less(t, first, KSUIDGenerator().NewID())
+}
+
+func TestULIDTimestamp(t *testing.T) {
+	now := time.Now().UnixMilli()
+	id := ULIDGenerator().NewID()
+	var ms int64
+	for _, c := range id[:10] { // The first 10 characters encode the 48 bit timestamp
+		ms = ms<<5 | int64(slices.Index([]byte(crockfordAlphabet), byte(c)))
+	}
+	assert.InDelta(t, now, ms, 1000)
+}
+
+func TestIDGeneratorConcurrent(t *testing.T) {
+	for _, gen := range []IDGenerator{ULIDGenerator(), SnowflakeGenerator(0)} {
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		seen := make(map[string]bool)
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 500 {
+					id := gen.NewID()
+					mu.Lock()
+					assert.False(t, seen[id], "duplicate ID: %v", id)
+					seen[id] = true
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestEncodeBase62(t *testing.T) {
+	assert.Equal(t, "000000000000000000000000000", encodeBase62(make([]byte, 20), ksuidLength))
+	maxID := make([]byte, 20)
+	for i := range maxID {
+		maxID[i] = 0xff
+	}
+	assert.Equal(t, "aWgEPTl1tmebfsQzFP4bxwgy80V", encodeBase62(maxID, ksuidLength), "the maximum KSUID")
+	assert.Equal(t, "10", encodeBase62([]byte{62}, 2))
+}
+
+func TestIDGeneratorOptions(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value"))
+	newCtx := newOptions([]Option{WithIDGenerator(UUIDv7Generator()), WithIDPrefix("eu1-")}).getSetRequestID(ctx)
+	reqID := RequestIDFromContext(newCtx)
+	if assert.Regexp(t, `^eu1-`, reqID) {
+		parsed, err := uuid.Parse(reqID[len("eu1-"):])
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Version(7), parsed.Version())
+	}
+
+	custom := WithIDGenerator(IDGeneratorFunc(func() string { return "custom-id" }))
+	newCtx = newOptions([]Option{custom, WithIDPrefix("svc.")}).getSetRequestID(ctx)
+	assert.Equal(t, "svc.custom-id", RequestIDFromContext(newCtx))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "incoming-id"))
+	newCtx = newOptions([]Option{custom, WithIDPrefix("svc.")}).getSetRequestID(ctx)
+	assert.Equal(t, "incoming-id", RequestIDFromContext(newCtx), "incoming IDs should not be prefixed")
+
+	var gotID string
+	err := ContextPropagationUnaryClientInterceptor(custom)(context.Background(), "/pkg.Service/Method", nil, nil, nil,
+		newTrailerInvoker(&gotID, metadata.Pairs(ResponseIDKey, "custom-id")))
+	assert.NoError(t, err)
+	assert.Equal(t, "custom-id", gotID)
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const (
+	LogLevelKey         = "x-log-level"        // Default metadata key used to request a per request log level
+	LevelOverrideLogKey = "log_level_override" // Log field recording an applied level override
+)
+
+// LevelOverrideAuthorizer decides whether the caller is allowed to override the log level of its request.
+type LevelOverrideAuthorizer func(ctx context.Context, level zapcore.Level) bool
+
+// levelOverride holds the per request log level override settings. Overrides are disabled unless authorised.
+type levelOverride struct {
+	header    string                  // Metadata key holding the requested level
+	authorize LevelOverrideAuthorizer // Authorisation callback
+	peers     []netip.Prefix          // Peers allowed to override the level
+}
+
+// WithLevelOverride enables per request log levels (i.e. x-log-level: debug), for the callers approved by authorize.
+// Only the context loggers of that request (logger.Ctx and ctxzap) are affected, the global level remains unchanged.
+func WithLevelOverride(authorize LevelOverrideAuthorizer) Option {
+	return func(o *options) {
+		o.levelOverride.authorize = authorize
+	}
+}
+
+// WithLevelOverridePeers enables per request log levels for the given peer IP addresses or CIDR ranges
+// (in addition to any authorisation callback). Invalid entries are ignored with a warning.
+func WithLevelOverridePeers(peers ...string) Option {
+	return func(o *options) {
+		for _, p := range peers {
+			prefix, err := parsePeerPrefix(p)
+			if err != nil {
+				logger.Sugar().Warnf("Ignoring invalid log level override peer '%v': %v", p, err)
+				continue
+			}
+			o.levelOverride.peers = append(o.levelOverride.peers, prefix)
+		}
+	}
+}
+
+// WithLevelOverrideHeader changes the metadata key used to request a per request log level (default: x-log-level).
+func WithLevelOverrideHeader(name string) Option {
+	return func(o *options) {
+		if len(name) > 0 {
+			o.levelOverride.header = strings.ToLower(name)
+		}
+	}
+}
+
+// parsePeerPrefix parses an IP address or CIDR range into a prefix.
+func parsePeerPrefix(p string) (netip.Prefix, error) {
+	p = strings.TrimSpace(p)
+	if strings.Contains(p, "/") {
+		prefix, err := netip.ParsePrefix(p)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(p)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// enabled reports whether level overrides have been enabled.
+func (lo *levelOverride) enabled() bool {
+	return lo.authorize != nil || len(lo.peers) > 0
+}
+
+// requested returns the level requested in the incoming metadata, if it is valid & authorised.
+func (lo *levelOverride) requested(ctx context.Context, md metadata.MD) (zapcore.Level, bool) {
+	if !lo.enabled() {
+		return zapcore.InvalidLevel, false
+	}
+	values := md.Get(lo.header)
+	if len(values) == 0 || len(strings.TrimSpace(values[0])) == 0 {
+		return zapcore.InvalidLevel, false
+	}
+	lvl, err := zapcore.ParseLevel(strings.TrimSpace(values[0]))
+	if err != nil {
+		ctxzap.Extract(ctx).Sugar().Debugf("Ignoring invalid log level override '%v': %v", values[0], err)
+		return zapcore.InvalidLevel, false
+	}
+	if lo.peerAllowed(ctx) || (lo.authorize != nil && lo.authorize(ctx, lvl)) {
+		return lvl, true
+	}
+	ctxzap.Extract(ctx).Sugar().Debugf("Unauthorised log level override '%v' ignored", lvl)
+	return zapcore.InvalidLevel, false
+}
+
+// peerAllowed reports whether the peer address of the request is in the allowlist.
+func (lo *levelOverride) peerAllowed(ctx context.Context) bool {
+	if len(lo.peers) == 0 {
+		return false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	var addr netip.Addr
+	if addrPort, err := netip.ParseAddrPort(p.Addr.String()); err == nil {
+		addr = addrPort.Addr()
+	} else if addr, err = netip.ParseAddr(p.Addr.String()); err != nil {
+		return false // i.e. unix sockets
+	}
+	addr = addr.Unmap()
+	for _, prefix := range lo.peers {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyLevelOverride makes the context loggers (logger.Ctx and ctxzap) of the request log at the given level.
+func applyLevelOverride(ctx context.Context, lvl zapcore.Level) context.Context {
+	ctx = logger.WithLevelOverride(ctx, lvl)
+	// Replace the ctxzap logger with an overridden copy. The tags are excluded, as they're re-added on every Extract
+	l := ctxzap.Extract(grpc_ctxtags.SetInContext(ctx, grpc_ctxtags.NewTags()))
+	return ctxzap.ToContext(ctx, logger.OverrideLevel(l, lvl))
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// newLevelTestContext sets up an info level logger and returns an incoming request context from the given peer.
+func newLevelTestContext(t *testing.T, peerAddr string, kv ...string) (context.Context, string) {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "level.log")
+	if err := logger.NewProdLoggerLevel(zapcore.InfoLevel, logFile); err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	ctx := ctxzap.ToContext(context.Background(), logger.Get())
+	if len(peerAddr) > 0 {
+		addr, err := net.ResolveTCPAddr("tcp", peerAddr)
+		if err != nil {
+			t.Fatalf("failed to parse TCP Addr: %v", err)
+		}
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(kv...)), logFile
+}
+
+// debugEnabled reports whether the request context loggers, and the global logger, are enabled for debug.
+func debugEnabled(ctx context.Context) (bool, bool, bool) {
+	return logger.Ctx(ctx).Core().Enabled(zapcore.DebugLevel),
+		ctxzap.Extract(ctx).Core().Enabled(zapcore.DebugLevel),
+		logger.Get().Core().Enabled(zapcore.DebugLevel)
+}
+
+func TestLevelOverrideDisabledByDefault(t *testing.T) {
+	ctx, _ := newLevelTestContext(t, "10.0.0.1:1234", LogLevelKey, "debug")
+	newCtx := getSetRequestID(ctx)
+	_, ok := logger.LevelOverride(newCtx)
+	assert.False(t, ok, "level override should be opt-in")
+	ctxLogger, zapLogger, global := debugEnabled(newCtx)
+	assert.False(t, ctxLogger || zapLogger || global, "debug should not be enabled")
+}
+
+func TestLevelOverrideAuthorizer(t *testing.T) {
+	var requested zapcore.Level
+	authorize := func(_ context.Context, lvl zapcore.Level) bool {
+		requested = lvl
+		return true
+	}
+	ctx, logFile := newLevelTestContext(t, "", LogLevelKey, " DEBUG ")
+	ctxzap.AddFields(ctx, zap.String("existing", "field"))
+	newCtx := newOptions([]Option{WithLevelOverride(authorize)}).getSetRequestID(ctx)
+	assert.Equal(t, zapcore.DebugLevel, requested)
+	lvl, ok := logger.LevelOverride(newCtx)
+	assert.True(t, ok)
+	assert.Equal(t, zapcore.DebugLevel, lvl)
+	ctxLogger, zapLogger, global := debugEnabled(newCtx)
+	assert.True(t, ctxLogger, "context logger should be enabled for debug")
+	assert.True(t, zapLogger, "ctxzap logger should be enabled for debug")
+	assert.False(t, global, "global level should not change")
+
+	ctxzap.Extract(newCtx).Debug("ctxzap debug")
+	logger.Get().Debug("global debug")
+	logger.SyncZap()
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if assert.Len(t, lines, 1) {
+		assert.Equal(t, 1, strings.Count(lines[0], `"existing"`), "fields should not be duplicated")
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "ctxzap debug", entry["msg"])
+		assert.Equal(t, "debug", entry[LevelOverrideLogKey])
+		assert.NotEmpty(t, entry[ReqLogKey])
+	}
+}
+
+func TestLevelOverrideUnauthorized(t *testing.T) {
+	ctx, _ := newLevelTestContext(t, "10.0.0.1:1234", LogLevelKey, "debug")
+	deny := func(context.Context, zapcore.Level) bool { return false }
+	newCtx := newOptions([]Option{WithLevelOverride(deny), WithLevelOverridePeers("192.168.0.0/16")}).getSetRequestID(ctx)
+	_, ok := logger.LevelOverride(newCtx)
+	assert.False(t, ok, "unauthorised override should be ignored")
+}
+
+func TestLevelOverridePeers(t *testing.T) {
+	tests := []struct {
+		peer    string
+		allowed bool
+	}{
+		{"10.1.2.3:5000", true},
+		{"192.168.1.1:5000", false},
+		{"127.0.0.1:5000", true},
+		{"[::1]:5000", true},
+		{"[2001:db8::1]:5000", false},
+	}
+	opts := []Option{WithLevelOverridePeers("10.0.0.0/8", "127.0.0.1", "::1", "not-an-ip")}
+	for _, test := range tests {
+		ctx, _ := newLevelTestContext(t, test.peer, LogLevelKey, "debug")
+		newCtx := newOptions(opts).getSetRequestID(ctx)
+		_, ok := logger.LevelOverride(newCtx)
+		assert.Equalf(t, test.allowed, ok, "unexpected override result for peer %v", test.peer)
+	}
+}
+
+func TestLevelOverrideHeader(t *testing.T) {
+	opts := []Option{WithLevelOverridePeers("127.0.0.1"), WithLevelOverrideHeader("X-Debug-Level")}
+	ctx, _ := newLevelTestContext(t, "127.0.0.1:5000", "x-debug-level", "warn")
+	lvl, ok := logger.LevelOverride(newOptions(opts).getSetRequestID(ctx))
+	assert.True(t, ok)
+	assert.Equal(t, zapcore.WarnLevel, lvl)
+
+	ctx, _ = newLevelTestContext(t, "127.0.0.1:5000", LogLevelKey, "debug")
+	_, ok = logger.LevelOverride(newOptions(opts).getSetRequestID(ctx))
+	assert.False(t, ok, "default header should be ignored when a custom one is configured")
+
+	ctx, _ = newLevelTestContext(t, "127.0.0.1:5000", "x-debug-level", "verbose")
+	_, ok = logger.LevelOverride(newOptions(opts).getSetRequestID(ctx))
+	assert.False(t, ok, "invalid levels should be ignored")
+}
+
+func TestLevelOverrideUnaryInterceptor(t *testing.T) {
+	ctx, _ := newLevelTestContext(t, "127.0.0.1:5000", LogLevelKey, "debug")
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		ctxLogger, zapLogger, global := debugEnabled(ctx)
+		assert.True(t, ctxLogger && zapLogger, "handler context loggers should be enabled for debug")
+		assert.False(t, global, "global level should not change")
+		return "output", nil
+	}
+	_, err := ContextPropagationUnaryServerInterceptor(WithLevelOverridePeers("127.0.0.1"))(ctx, "xyz", unaryInfo, handler)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.opentelemetry.io/otel/baggage"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	BaggageKey       = "baggage"    // W3C Baggage metadata key
+	BaggageLogPrefix = "baggage."   // Logging field prefix of baggage entries (so they can't replace other fields, i.e. reqId)
+	RedactedValue    = "[REDACTED]" // Value logged for redacted metadata
+	hashLength       = 16           // Number of hex characters kept from a hashed value
+)
+
+// MetadataTransform transforms an incoming metadata value before it is logged (i.e. to hash or redact it).
+type MetadataTransform func(value string) string
+
+// HashTransform replaces the value with a (truncated) SHA-256 hash, so it can be correlated without being exposed.
+func HashTransform() MetadataTransform {
+	return func(value string) string {
+		sum := sha256.Sum256([]byte(value))
+		return hex.EncodeToString(sum[:])[:hashLength]
+	}
+}
+
+// TruncateTransform truncates the value to the given number of characters.
+func TruncateTransform(maxLength int) MetadataTransform {
+	return func(value string) string {
+		if runes := []rune(value); len(runes) > maxLength {
+			return string(runes[:max(maxLength, 0)])
+		}
+		return value
+	}
+}
+
+// RedactTransform replaces the value with [REDACTED], only recording that it was present.
+func RedactTransform() MetadataTransform {
+	return func(string) string {
+		return RedactedValue
+	}
+}
+
+// MetadataField describes an incoming metadata key to add to the logging fields.
+type MetadataField struct {
+	Key       string            // Incoming metadata key (i.e. x-tenant-id)
+	LogKey    string            // Logging field name (default: the metadata key)
+	Transform MetadataTransform // Optional transformation of the value (i.e. HashTransform)
+	Propagate bool              // Also add the (transformed) value to the outgoing W3C baggage, under the logging field name
+}
+
+// WithMetadataFields adds the given (allowlisted) incoming metadata keys to the logging fields of the request.
+// Binary (-bin) keys are not supported.
+func WithMetadataFields(fields ...MetadataField) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			f.Key = strings.ToLower(strings.TrimSpace(f.Key))
+			if len(f.Key) == 0 || strings.HasSuffix(f.Key, "-bin") {
+				continue
+			}
+			if len(f.LogKey) == 0 {
+				f.LogKey = f.Key
+			}
+			o.metadataFields = append(o.metadataFields, f)
+		}
+	}
+}
+
+// WithBaggageFields adds the given W3C baggage entries (from the OpenTelemetry context or incoming baggage header)
+// to the logging fields of the request, under the baggage key prefixed with BaggageLogPrefix (i.e. baggage.tenant).
+// All entries are added if no keys are given.
+func WithBaggageFields(keys ...string) Option {
+	return func(o *options) {
+		o.baggage = true
+		o.baggageKeys = append(o.baggageKeys, keys...)
+	}
+}
+
+// metadataLogFields returns the logging fields for the allowlisted metadata & baggage entries of the request,
+// also adding any propagated fields to the outgoing baggage.
+func (o *options) metadataLogFields(ctx context.Context, md, out metadata.MD) []zap.Field {
+	var fields []zap.Field
+	if o.baggage {
+		bag := incomingBaggage(ctx, md)
+		members := bag.Members()
+		slices.SortFunc(members, func(a, b baggage.Member) int { return strings.Compare(a.Key(), b.Key()) })
+		for _, m := range members {
+			if len(o.baggageKeys) == 0 || slices.Contains(o.baggageKeys, m.Key()) {
+				fields = append(fields, zap.String(BaggageLogPrefix+m.Key(), SanitiseValue(m.Value())))
+			}
+		}
+	}
+	var propagate []baggage.Member
+	for _, f := range o.metadataFields {
+		value := firstValue(md, f.Key)
+		if len(value) == 0 {
+			continue
+		}
+		if f.Transform != nil {
+			value = f.Transform(value)
+		}
+		fields = append(fields, zap.String(f.LogKey, SanitiseValue(value)))
+		if f.Propagate {
+			m, err := baggage.NewMemberRaw(f.LogKey, value)
+			if err != nil {
+				ctxzap.Extract(ctx).Sugar().Debugf("Unable to propagate metadata '%v' in the baggage: %v", f.Key, err)
+				continue
+			}
+			propagate = append(propagate, m)
+		}
+	}
+	if len(propagate) > 0 {
+		setOutgoingBaggage(ctx, out, propagate)
+	}
+	return fields
+}
+
+// incomingBaggage returns the W3C baggage of the request, from the OpenTelemetry context if present,
+// otherwise from the incoming baggage header(s).
+func incomingBaggage(ctx context.Context, md metadata.MD) baggage.Baggage {
+	if bag := baggage.FromContext(ctx); bag.Len() > 0 {
+		return bag
+	}
+	bag, err := baggage.Parse(strings.Join(md.Get(BaggageKey), ","))
+	if err != nil {
+		ctxzap.Extract(ctx).Sugar().Debugf("Ignoring invalid baggage: %v", err)
+		return baggage.Baggage{}
+	}
+	return bag
+}
+
+// setOutgoingBaggage adds the given members to the outgoing baggage header (replacing any with the same key).
+func setOutgoingBaggage(ctx context.Context, out metadata.MD, members []baggage.Member) {
+	bag := incomingBaggage(ctx, out)
+	for _, m := range members {
+		updated, err := bag.SetMember(m)
+		if err != nil {
+			ctxzap.Extract(ctx).Sugar().Debugf("Unable to propagate '%v' in the baggage: %v", m.Key(), err)
+			continue
+		}
+		bag = updated
+	}
+	out.Set(BaggageKey, bag.String())
+}
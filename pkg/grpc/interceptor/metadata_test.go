@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/baggage"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMetadataTransforms(t *testing.T) {
+	sum := sha256.Sum256([]byte("key-123"))
+	assert.Equal(t, hex.EncodeToString(sum[:])[:hashLength], HashTransform()("key-123"))
+	assert.Equal(t, "héll", TruncateTransform(4)("héllo"))
+	assert.Equal(t, "abc", TruncateTransform(10)("abc"))
+	assert.Equal(t, RedactedValue, RedactTransform()("secret"))
+}
+
+func TestMetadataFields(t *testing.T) {
+	opts := []Option{WithMetadataFields(
+		MetadataField{Key: "X-Tenant-ID", LogKey: "tenant"},
+		MetadataField{Key: "x-client-version", Transform: TruncateTransform(5)},
+		MetadataField{Key: "x-api-key-id", LogKey: "api_key", Transform: HashTransform()},
+		MetadataField{Key: "authorization", Transform: RedactTransform()},
+		MetadataField{Key: "x-missing"},
+		MetadataField{Key: "x-trace-bin"},
+	)}
+	md := metadata.Pairs(RequestIDKey, "meta-id", "x-tenant-id", "acme", "x-client-version", "1.2.3-beta",
+		"x-api-key-id", "key-123", "authorization", "Bearer secret", "x-other", "not logged", "x-trace-bin", "binary")
+	ctx, fields := traceFields(md, opts...)
+	assert.Equal(t, map[string]interface{}{
+		ReqLogKey:          "meta-id",
+		"tenant":           "acme",
+		"x-client-version": "1.2.3",
+		"api_key":          HashTransform()("key-123"),
+		"authorization":    RedactedValue,
+	}, fields)
+	out, _ := metadata.FromOutgoingContext(ctx)
+	assert.Empty(t, out.Get(BaggageKey), "nothing should be propagated by default")
+}
+
+func TestMetadataFieldsSanitised(t *testing.T) {
+	_, fields := traceFields(metadata.Pairs("x-tenant-id", "acme\nfake=entry"), WithMetadataFields(MetadataField{Key: "x-tenant-id"}))
+	assert.Equal(t, "acme?fake=entry", fields["x-tenant-id"])
+}
+
+func TestMetadataFieldsPropagated(t *testing.T) {
+	opts := []Option{WithMetadataFields(
+		MetadataField{Key: "x-tenant-id", LogKey: "tenant", Propagate: true},
+		MetadataField{Key: "x-api-key-id", LogKey: "api_key", Transform: HashTransform(), Propagate: true},
+	)}
+	md := metadata.Pairs("x-tenant-id", "acme corp", "x-api-key-id", "key-123", BaggageKey, "user=alice,tenant=old")
+	ctx, _ := traceFields(md, opts...)
+	out, _ := metadata.FromOutgoingContext(ctx)
+	bag, err := baggage.Parse(firstValue(out, BaggageKey))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "acme corp", bag.Member("tenant").Value(), "the propagated value should replace the existing one")
+		assert.Equal(t, HashTransform()("key-123"), bag.Member("api_key").Value(), "the transformed value should be propagated")
+		assert.Equal(t, "alice", bag.Member("user").Value(), "existing baggage should be kept")
+	}
+	in, _ := metadata.FromIncomingContext(ctx)
+	assert.Equal(t, []string{"user=alice,tenant=old"}, in.Get(BaggageKey), "the incoming baggage should not change")
+
+	// A downstream service logs the propagated baggage
+	_, fields := traceFields(metadata.Pairs(BaggageKey, firstValue(out, BaggageKey)), WithBaggageFields("tenant", "api_key"))
+	assert.Equal(t, "acme corp", fields["baggage.tenant"])
+	assert.Equal(t, HashTransform()("key-123"), fields["baggage.api_key"])
+	assert.NotContains(t, fields, "baggage.user")
+}
+
+func TestBaggageFields(t *testing.T) {
+	md := metadata.Pairs(BaggageKey, "tenant=acme,user=alice;prop=1", BaggageKey, "region=eu%201")
+	_, fields := traceFields(md, WithBaggageFields())
+	assert.Equal(t, "acme", fields["baggage.tenant"])
+	assert.Equal(t, "alice", fields["baggage.user"])
+	assert.Equal(t, "eu 1", fields["baggage.region"])
+
+	_, fields = traceFields(md, WithBaggageFields("user"))
+	assert.Equal(t, "alice", fields["baggage.user"])
+	assert.NotContains(t, fields, "baggage.tenant")
+
+	_, fields = traceFields(metadata.Pairs(RequestIDKey, "bag-id", BaggageKey, "invalid baggage"), WithBaggageFields())
+	assert.Equal(t, map[string]interface{}{ReqLogKey: "bag-id"}, fields, "invalid baggage should be ignored")
+
+	_, fields = traceFields(md)
+	assert.NotContains(t, fields, "baggage.tenant", "baggage should not be logged by default")
+}
+
+func TestBaggageFieldsFromOTelContext(t *testing.T) {
+	member, _ := baggage.NewMember("tenant", "otel")
+	bag, _ := baggage.New(member)
+	ctx := baggage.ContextWithBaggage(context.Background(), bag)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(BaggageKey, "tenant=header"))
+	newCtx := newOptions([]Option{WithBaggageFields("tenant")}).getSetRequestID(ctx)
+	assert.Equal(t, "otel", fieldMap(newCtx)["baggage.tenant"])
+}
+
+func TestBaggageFieldsNamespaced(t *testing.T) {
+	md := metadata.Pairs(RequestIDKey, "real-id", BaggageKey, "reqId=spoofed,trace_id=spoofed,log_level_override=debug")
+	_, fields := traceFields(md, WithBaggageFields())
+	assert.Equal(t, "real-id", fields[ReqLogKey], "baggage should not replace the Request ID")
+	assert.NotContains(t, fields, TraceLogKey)
+	assert.NotContains(t, fields, LevelOverrideLogKey)
+	assert.Equal(t, "spoofed", fields["baggage.reqId"])
+	assert.Equal(t, "spoofed", fields["baggage.trace_id"])
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// ResponseIDPlacement controls where the Response ID is returned to the client.
+type ResponseIDPlacement int
+
+// Supported Response ID placements.
+const (
+	ResponseIDTrailer ResponseIDPlacement = iota // Return the Response ID in the trailer (default)
+	ResponseIDHeader                             // Return the Response ID in the response header
+	ResponseIDBoth                               // Return the Response ID in both the header & trailer
+	ResponseIDNone                               // Don't return a Response ID
+)
+
+// LogFields selects the fields added to the logging context.
+type LogFields uint8
+
+// Log fields which can be added to the logging context.
+const (
+	LogRequestID    LogFields = 1 << iota // The Request ID
+	LogTraceID                            // The (sampled) Trace ID
+	LogSpanID                             // The (sampled) Span ID
+	LogTraceSampled                       // Whether the trace is sampled
+
+	LogAllFields = LogRequestID | LogTraceID | LogSpanID | LogTraceSampled // All fields (default)
+)
+
+// Option configures the behaviour of the context propagation interceptors.
+type Option func(*options)
+
+// options holds the settings supplied to the context propagation interceptors.
+type options struct {
+	requestHeaders  []string            // Incoming Request ID header names, in priority order (the first is also used outgoing)
+	responseHeader  string              // Response ID header name
+	placement       ResponseIDPlacement // Where to return the Response ID
+	overwrite       bool                // Write the Request ID back into the incoming metadata
+	reqLogKey       string              // Request ID logging key
+	traceLogKey     string              // Trace ID logging key
+	spanLogKey      string              // Span ID logging key
+	logFields       LogFields           // Fields to add to the logging context
+	idGenerator     IDGenerator         // Creates new Request IDs
+	idPrefix        string              // Prefix added to new Request IDs (i.e. a service or region code)
+	unsampledTraces bool                // Log the trace/span IDs of unsampled traces
+	metadataFields  []MetadataField     // Incoming metadata keys to add to the logging fields
+	baggage         bool                // Add W3C baggage entries to the logging fields
+	baggageKeys     []string            // Baggage entries to log (all if empty)
+	validation      RequestIDValidation // Incoming Request ID validation settings
+	levelOverride   levelOverride       // Per request log level override settings
+}
+
+// WithRequestIDHeaders sets the metadata keys checked (in order) for an incoming Request ID (default: x-request-id).
+// The first key is used to propagate the Request ID downstream.
+func WithRequestIDHeaders(headers ...string) Option {
+	return func(o *options) {
+		var keys []string
+		for _, h := range headers {
+			if h = strings.ToLower(strings.TrimSpace(h)); len(h) > 0 {
+				keys = append(keys, h)
+			}
+		}
+		if len(keys) > 0 {
+			o.requestHeaders = keys
+		}
+	}
+}
+
+// WithResponseIDHeader sets the metadata key used to return the Response ID (default: x-response-id).
+func WithResponseIDHeader(header string) Option {
+	return func(o *options) {
+		if header = strings.ToLower(strings.TrimSpace(header)); len(header) > 0 {
+			o.responseHeader = header
+		}
+	}
+}
+
+// WithResponseIDPlacement sets where the Response ID is returned (server) or checked (client): trailer (default), header, both or none.
+func WithResponseIDPlacement(placement ResponseIDPlacement) Option {
+	return func(o *options) {
+		o.placement = placement
+	}
+}
+
+// WithOverwriteMetadata controls whether the Request ID is written back into the incoming metadata (default: true),
+// i.e. when it was generated or supplied under an alternative header.
+func WithOverwriteMetadata(overwrite bool) Option {
+	return func(o *options) {
+		o.overwrite = overwrite
+	}
+}
+
+// WithRequestIDLogKey sets the logging key of the Request ID (default: reqId).
+// Note: the cloud provider log schemas only remap the default keys.
+func WithRequestIDLogKey(key string) Option {
+	return func(o *options) {
+		if len(key) > 0 {
+			o.reqLogKey = key
+		}
+	}
+}
+
+// WithTraceIDLogKey sets the logging key of the Trace ID (default: trace_id).
+func WithTraceIDLogKey(key string) Option {
+	return func(o *options) {
+		if len(key) > 0 {
+			o.traceLogKey = key
+		}
+	}
+}
+
+// WithSpanIDLogKey sets the logging key of the Span ID (default: span_id).
+func WithSpanIDLogKey(key string) Option {
+	return func(o *options) {
+		if len(key) > 0 {
+			o.spanLogKey = key
+		}
+	}
+}
+
+// WithLogFields selects which fields are added to the logging context (default: LogAllFields).
+func WithLogFields(fields LogFields) Option {
+	return func(o *options) {
+		o.logFields = fields
+	}
+}
+
+// WithIDGenerator sets the generator used to create new Request IDs (default: UUIDv4Generator).
+// Time-ordered IDs can be created using UUIDv7Generator, ULIDGenerator, KSUIDGenerator or SnowflakeGenerator,
+// or a custom function using IDGeneratorFunc.
+func WithIDGenerator(gen IDGenerator) Option {
+	return func(o *options) {
+		if gen != nil {
+			o.idGenerator = gen
+		}
+	}
+}
+
+// WithIDPrefix adds the given prefix (i.e. a service or region code, such as "eu1-") to newly created Request IDs.
+// Incoming Request IDs are left unchanged.
+func WithIDPrefix(prefix string) Option {
+	return func(o *options) {
+		o.idPrefix = prefix
+	}
+}
+
+// newOptions applies the supplied options on top of the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{
+		requestHeaders: []string{RequestIDKey},
+		responseHeader: ResponseIDKey,
+		placement:      ResponseIDTrailer,
+		overwrite:      true,
+		reqLogKey:      ReqLogKey,
+		traceLogKey:    TraceLogKey,
+		spanLogKey:     SpanLogKey,
+		logFields:      LogAllFields,
+		idGenerator:    UUIDv4Generator(),
+		validation:     DefaultRequestIDValidation(),
+		levelOverride:  levelOverride{header: LogLevelKey},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// newRequestID creates a new (prefixed) Request ID.
+func (o *options) newRequestID() string {
+	return o.idPrefix + o.idGenerator.NewID()
+}
+
+// requestHeader returns the metadata key used to propagate the Request ID.
+func (o *options) requestHeader() string {
+	return o.requestHeaders[0]
+}
+
+// incomingRequestID returns the first non-empty Request ID found in the metadata, along with its key.
+func (o *options) incomingRequestID(md metadata.MD) (string, string) {
+	for _, key := range o.requestHeaders {
+		if ids := md.Get(key); len(ids) > 0 {
+			if reqID := strings.TrimSpace(ids[0]); len(reqID) > 0 {
+				return reqID, key
+			}
+		}
+	}
+	return "", ""
+}
+
+// responseInHeader reports whether the Response ID is returned in the response header.
+func (o *options) responseInHeader() bool {
+	return o.placement == ResponseIDHeader || o.placement == ResponseIDBoth
+}
+
+// responseInTrailer reports whether the Response ID is returned in the trailer.
+func (o *options) responseInTrailer() bool {
+	return o.placement == ResponseIDTrailer || o.placement == ResponseIDBoth
+}
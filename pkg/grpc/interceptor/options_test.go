@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// testTransportStream records the response header & trailer set by the server interceptors.
+type testTransportStream struct {
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+func (s *testTransportStream) Method() string { return "/pkg.Service/Method" }
+
+func (s *testTransportStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *testTransportStream) SendHeader(md metadata.MD) error { return s.SetHeader(md) }
+
+func (s *testTransportStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// newTransportContext returns an incoming context with the given metadata and a recording transport stream.
+func newTransportContext(kv ...string) (context.Context, *testTransportStream) {
+	stream := &testTransportStream{}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	return grpc.NewContextWithServerTransportStream(ctx, stream), stream
+}
+
+// fieldMap returns the context logging fields as a key/value map.
+func fieldMap(ctx context.Context) map[string]string {
+	fields := make(map[string]string)
+	for _, f := range logger.Fields(ctx) {
+		fields[f.Key] = f.String
+	}
+	return fields
+}
+
+func TestOptionsDefaults(t *testing.T) {
+	ctx, stream := newTransportContext(RequestIDKey, "default-id")
+	newCtx := getSetRequestID(ctx)
+	assert.Equal(t, "default-id", RequestIDFromContext(newCtx))
+	assert.Equal(t, map[string]string{ReqLogKey: "default-id"}, fieldMap(newCtx))
+	assert.Equal(t, []string{"default-id"}, stream.trailer.Get(ResponseIDKey))
+	assert.Empty(t, stream.header)
+	md, _ := metadata.FromOutgoingContext(newCtx)
+	assert.Equal(t, []string{"default-id"}, md.Get(RequestIDKey))
+}
+
+func TestOptionsRequestIDHeaders(t *testing.T) {
+	opts := []Option{WithRequestIDHeaders("X-Correlation-ID", RequestIDKey)}
+	ctx, _ := newTransportContext(RequestIDKey, "fallback-id")
+	newCtx := newOptions(opts).getSetRequestID(ctx)
+	assert.Equal(t, "fallback-id", RequestIDFromContext(newCtx))
+	out, _ := metadata.FromOutgoingContext(newCtx)
+	assert.Equal(t, []string{"fallback-id"}, out.Get("x-correlation-id"), "the first header should be used downstream")
+	in, _ := metadata.FromIncomingContext(newCtx)
+	assert.Equal(t, []string{"fallback-id"}, in.Get("x-correlation-id"), "the incoming metadata should be overwritten")
+
+	ctx, _ = newTransportContext("x-correlation-id", "primary-id", RequestIDKey, "fallback-id")
+	newCtx = newOptions(opts).getSetRequestID(ctx)
+	assert.Equal(t, "primary-id", RequestIDFromContext(newCtx), "headers should be checked in order")
+}
+
+func TestOptionsNoOverwrite(t *testing.T) {
+	ctx, _ := newTransportContext("other", "value")
+	newCtx := newOptions([]Option{WithOverwriteMetadata(false)}).getSetRequestID(ctx)
+	reqID := RequestIDFromContext(newCtx)
+	assert.NotEmpty(t, reqID)
+	in, _ := metadata.FromIncomingContext(newCtx)
+	assert.Empty(t, in.Get(RequestIDKey), "the incoming metadata should be left untouched")
+	out, _ := metadata.FromOutgoingContext(newCtx)
+	assert.Equal(t, []string{reqID}, out.Get(RequestIDKey), "the generated ID should still be propagated")
+
+	newCtx = getSetRequestID(ctx)
+	in, _ = metadata.FromIncomingContext(newCtx)
+	assert.Equal(t, []string{RequestIDFromContext(newCtx)}, in.Get(RequestIDKey), "the incoming metadata should be overwritten by default")
+}
+
+func TestOptionsResponsePlacement(t *testing.T) {
+	tests := []struct {
+		placement ResponseIDPlacement
+		header    bool
+		trailer   bool
+	}{
+		{placement: ResponseIDTrailer, trailer: true},
+		{placement: ResponseIDHeader, header: true},
+		{placement: ResponseIDBoth, header: true, trailer: true},
+		{placement: ResponseIDNone},
+	}
+	for _, tt := range tests {
+		ctx, stream := newTransportContext(RequestIDKey, "placement-id")
+		newOptions([]Option{WithResponseIDPlacement(tt.placement), WithResponseIDHeader("X-Trace-Response")}).getSetRequestID(ctx)
+		assert.Equal(t, tt.header, len(stream.header.Get("x-trace-response")) > 0, "header for placement %v", tt.placement)
+		assert.Equal(t, tt.trailer, len(stream.trailer.Get("x-trace-response")) > 0, "trailer for placement %v", tt.placement)
+		assert.Empty(t, stream.trailer.Get(ResponseIDKey))
+	}
+}
+
+func TestOptionsLogFields(t *testing.T) {
+	ctx, _ := newTransportContext(RequestIDKey, "log-id")
+	newCtx := newOptions([]Option{WithRequestIDLogKey("request_id")}).getSetRequestID(ctx)
+	assert.Equal(t, map[string]string{"request_id": "log-id"}, fieldMap(newCtx))
+
+	newCtx = newOptions([]Option{WithLogFields(0)}).getSetRequestID(ctx)
+	assert.Empty(t, fieldMap(newCtx))
+	assert.Equal(t, "log-id", RequestIDFromContext(newCtx), "the Request ID should still be available")
+}
+
+func TestOptionsClient(t *testing.T) {
+	logs := observeLogs(t)
+	opts := []Option{WithRequestIDHeaders("x-correlation-id"), WithResponseIDHeader("x-correlation-response"), WithResponseIDPlacement(ResponseIDHeader)}
+	var gotMD metadata.MD
+	invoker := func(ctx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		gotMD, _ = metadata.FromOutgoingContext(ctx)
+		for _, opt := range opts {
+			if h, ok := opt.(grpc.HeaderCallOption); ok {
+				*h.HeaderAddr = metadata.Pairs("x-correlation-response", "other-id")
+			}
+		}
+		return nil
+	}
+	ctx := ContextWithRequestID(context.Background(), "client-id")
+	err := ContextPropagationUnaryClientInterceptor(opts...)(ctx, "/pkg.Service/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"client-id"}, gotMD.Get("x-correlation-id"))
+	assert.Empty(t, gotMD.Get(RequestIDKey))
+	mismatch := logs.FilterMessage("Response ID does not match the Request ID").All()
+	if assert.Len(t, mismatch, 1, "the header should be checked") {
+		assert.Equal(t, "other-id", mismatch[0].ContextMap()["response_id"])
+	}
+
+	logs.TakeAll()
+	err = ContextPropagationUnaryClientInterceptor(WithResponseIDPlacement(ResponseIDNone))(ctx, "/pkg.Service/Method", nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, logs.Len(), "nothing should be checked (or logged)")
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryHandler converts a recovered panic into the error returned to the client.
+type RecoveryHandler func(ctx context.Context, p any) error
+
+// RecoveryOption configures the behaviour of the recovery interceptors.
+type RecoveryOption func(*recoveryOptions)
+
+// recoveryOptions holds the settings supplied to the recovery interceptors.
+type recoveryOptions struct {
+	handler RecoveryHandler // Custom conversion of a panic into an error
+	level   zapcore.Level   // Level to log panics at
+}
+
+// WithRecoveryHandler uses the given handler to build the error returned to the client (after the panic has been logged).
+// If it returns nil, the default codes.Internal error is returned.
+func WithRecoveryHandler(handler RecoveryHandler) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.handler = handler
+	}
+}
+
+// WithRecoveryLevel sets the level to log panics at (default: error).
+func WithRecoveryLevel(lvl zapcore.Level) RecoveryOption {
+	return func(o *recoveryOptions) {
+		o.level = lvl
+	}
+}
+
+// newRecoveryOptions applies the supplied options on top of the defaults.
+func newRecoveryOptions(opts []RecoveryOption) *recoveryOptions {
+	o := &recoveryOptions{level: zapcore.ErrorLevel}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// RecoveryUnaryServerInterceptor recovers from panics in unary handlers, logging them (with the stack and request context)
+// and returning codes.Internal, including the Request ID. It should be chained after ContextPropagationUnaryServerInterceptor,
+// so that the Request ID is available.
+func RecoveryUnaryServerInterceptor(opts ...RecoveryOption) grpc.UnaryServerInterceptor {
+	o := newRecoveryOptions(opts)
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		var resp interface{}
+		var err error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = o.recovered(ctx, info.FullMethod, r)
+				}
+			}()
+			resp, err = handler(ctx, req)
+		}()
+		return resp, err
+	}
+}
+
+// RecoveryStreamServerInterceptor recovers from panics in stream handlers, logging them (with the stack and request context)
+// and returning codes.Internal, including the Request ID. It should be chained after ContextPropagationStreamServerInterceptor,
+// so that the Request ID is available.
+func RecoveryStreamServerInterceptor(opts ...RecoveryOption) grpc.StreamServerInterceptor {
+	o := newRecoveryOptions(opts)
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		var err error
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = o.recovered(stream.Context(), info.FullMethod, r)
+				}
+			}()
+			err = handler(srv, stream)
+		}()
+		return err
+	}
+}
+
+// recovered logs the recovered panic and returns the error to send to the client.
+// It must be called directly from the deferred function, so that the panicking stack is logged.
+func (o *recoveryOptions) recovered(ctx context.Context, fullMethod string, r any) error {
+	l := ctxzap.Extract(ctx)
+	if !l.Core().Enabled(zapcore.FatalLevel) { // No ctxzap logger in the context, so use the global one
+		l = logger.Ctx(ctx)
+	}
+	logger.LogPanic(r, logger.WithRecoverLogger(l.With(zap.String(FullMethodLogKey, fullMethod))), logger.WithRecoverLevel(o.level))
+	if o.handler != nil {
+		if err := o.handler(ctx, r); err != nil {
+			return err
+		}
+	}
+	return internalError(RequestIDFromContext(ctx))
+}
+
+// internalError returns a codes.Internal error, carrying the Request ID in its message and details (if known).
+func internalError(reqID string) error {
+	if len(reqID) == 0 {
+		return status.Error(codes.Internal, "internal error")
+	}
+	st := status.New(codes.Internal, fmt.Sprintf("internal error (request id: %v)", reqID))
+	if detailed, err := st.WithDetails(&errdetails.RequestInfo{RequestId: reqID}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
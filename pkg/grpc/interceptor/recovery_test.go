@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// recoveryCall runs a panicking unary call through the context propagation & recovery interceptors.
+func recoveryCall(ctx context.Context, opts ...RecoveryOption) error {
+	recovery := RecoveryUnaryServerInterceptor(opts...)
+	info := &grpc.UnaryServerInfo{FullMethod: "/pkg.Service/Panic"}
+	_, err := ContextPropagationUnaryServerInterceptor()(ctx, wrapperspb.String("request"), info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return recovery(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+				panic("handler exploded")
+			})
+		})
+	return err
+}
+
+func TestRecoveryUnary(t *testing.T) {
+	logs := observeLogs(t)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "panic-id"))
+	err := recoveryCall(ctx)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Contains(t, st.Message(), "panic-id")
+	if assert.Len(t, st.Details(), 1) {
+		info, ok := st.Details()[0].(*errdetails.RequestInfo)
+		if assert.True(t, ok) {
+			assert.Equal(t, "panic-id", info.GetRequestId())
+		}
+	}
+	entries := logs.FilterMessage("Recovered from panic: handler exploded").All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "panic-id", fields[ReqLogKey])
+		assert.Equal(t, "/pkg.Service/Panic", fields[FullMethodLogKey])
+		stack, ok := fields["stack"].([]interface{})
+		if assert.True(t, ok) && assert.NotEmpty(t, stack) {
+			frame, _ := stack[0].(map[string]interface{})
+			assert.True(t, strings.Contains(frame["function"].(string), "recoveryCall"), "the stack should start at the panic: %v", frame)
+		}
+	}
+}
+
+func TestRecoveryCtxzapFields(t *testing.T) {
+	observeLogs(t)
+	core, logs := observer.New(zap.DebugLevel)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "ctxzap-id"))
+	ctx = grpc_ctxtags.SetInContext(ctx, grpc_ctxtags.NewTags().Set("peer.address", "10.0.0.1"))
+	ctx = ctxzap.ToContext(ctx, zap.New(core))
+	_ = recoveryCall(ctx, WithRecoveryLevel(zapcore.WarnLevel))
+
+	entries := logs.FilterMessage("Recovered from panic: handler exploded").All()
+	if assert.Len(t, entries, 1, "the panic should be logged to the ctxzap logger") {
+		assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+		fields := entries[0].ContextMap()
+		assert.Equal(t, "ctxzap-id", fields[ReqLogKey])
+		assert.Equal(t, "10.0.0.1", fields["peer.address"])
+	}
+}
+
+func TestRecoveryHandler(t *testing.T) {
+	logs := observeLogs(t)
+	var got any
+	custom := status.Error(codes.Unavailable, "try again")
+	err := recoveryCall(context.Background(), WithRecoveryHandler(func(_ context.Context, p any) error {
+		got = p
+		return custom
+	}))
+	assert.Equal(t, "handler exploded", got)
+	assert.ErrorIs(t, err, custom)
+	assert.Equal(t, 1, logs.FilterMessage("Recovered from panic: handler exploded").Len(), "the panic should still be logged")
+
+	err = recoveryCall(context.Background(), WithRecoveryHandler(func(context.Context, any) error { return nil }))
+	assert.Equal(t, codes.Internal, status.Code(err), "a nil handler error should fall back to codes.Internal")
+}
+
+func TestRecoveryStream(t *testing.T) {
+	observeLogs(t)
+	stream := &testServerStream{ctx: context.Background()}
+	err := RecoveryStreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Stream"},
+		func(interface{}, grpc.ServerStream) error { panic(errors.New("stream exploded")) })
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal error", status.Convert(err).Message())
+
+	err = RecoveryStreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/pkg.Service/Stream"},
+		func(interface{}, grpc.ServerStream) error { return nil })
+	assert.NoError(t, err)
+}
@@ -27,22 +27,25 @@ package interceptor
 
 import (
 	"context"
-	"strings"
+	"sync/atomic"
+	"time"
 
-	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
-	oteltrace "go.opentelemetry.io/otel/trace"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	RequestIDKey  = "x-request-id"
 	ResponseIDKey = "x-response-id"
-	ReqLogKey     = "reqId"
-	SpanLogKey    = "span_id"
-	TraceLogKey   = "trace_id"
+	ReqLogKey     = logger.RequestIDLogKey
+	SpanLogKey    = logger.SpanIDLogKey
+	TraceLogKey   = logger.TraceIDLogKey
+
+	FullMethodLogKey = "grpc.full_method" // Log field holding the full gRPC method name (/package.Service/Method)
 )
 
 type requestIDKey struct{} // Used for storing the request ID in a context
@@ -50,14 +53,18 @@ type requestIDKey struct{} // Used for storing the request ID in a context
 // ContextPropagationUnaryServerInterceptor intercepts the incoming unary request and checks for a Request ID.
 // If none exists, create it, add it to the logging dataset and set the Response ID
 // It also adds the Request ID to any new outgoing (downstream) requests.
-func ContextPropagationUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+func ContextPropagationUnaryServerInterceptor(opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
 	return func(
 		ctx context.Context,
 		req interface{},
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		ctx = getSetRequestID(ctx)
+		if err := o.rejectInvalidRequestID(ctx); err != nil {
+			return nil, err
+		}
+		ctx = o.getSetRequestID(ctx)
 		return handler(ctx, req)
 	}
 }
@@ -65,64 +72,152 @@ func ContextPropagationUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 // ContextPropagationStreamServerInterceptor intercepts the incoming stream request and checks for a Request ID.
 // If none exists, create it, add it to the logging dataset and set the Response ID
 // It also adds the Request ID to any new outgoing (downstream) requests.
-func ContextPropagationStreamServerInterceptor() grpc.StreamServerInterceptor {
+// Once the stream closes, the number of messages sent/received, duration and status are logged (at debug level).
+func ContextPropagationStreamServerInterceptor(opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
 	return func(
 		srv interface{},
 		stream grpc.ServerStream,
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
+		start := time.Now()
 		ctx := stream.Context()
-		ctx = getSetRequestID(ctx)
-		stream = newServerStreamWithContext(stream, ctx)
-		return handler(srv, stream)
+		if err := o.rejectInvalidRequestID(ctx); err != nil {
+			return err
+		}
+		ctx = o.getSetRequestID(ctx)
+		wrapped := newServerStreamWithContext(stream, ctx)
+		err := handler(srv, wrapped)
+		wrapped.logClosed(info.FullMethod, time.Since(start), err)
+		return err
 	}
 }
 
+// serverStreamWithContext is a Server Stream which exposes the updated request context to the handler,
+// counting the messages sent & received.
 type serverStreamWithContext struct {
 	grpc.ServerStream
-	ctx context.Context
+	ctx      context.Context
+	sent     atomic.Int64
+	received atomic.Int64
 }
 
 // newServerStreamWithContext returns a new Server Stream with context.
-func newServerStreamWithContext(stream grpc.ServerStream, ctx context.Context) grpc.ServerStream {
-	return serverStreamWithContext{
+func newServerStreamWithContext(stream grpc.ServerStream, ctx context.Context) *serverStreamWithContext {
+	return &serverStreamWithContext{
 		ServerStream: stream,
 		ctx:          ctx,
 	}
 }
 
+// Context returns the request context, including the Request ID, logging fields and outgoing metadata.
+func (s *serverStreamWithContext) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends a message on the stream, counting it if successful.
+func (s *serverStreamWithContext) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.sent.Add(1)
+	}
+	return err
+}
+
+// RecvMsg receives a message from the stream, counting it if successful.
+func (s *serverStreamWithContext) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.received.Add(1)
+	}
+	return err
+}
+
+// logClosed logs the stream summary: messages sent/received, duration and the close status.
+func (s *serverStreamWithContext) logClosed(method string, duration time.Duration, err error) {
+	logger.Ctx(s.ctx).Debug("Stream closed",
+		zap.String(FullMethodLogKey, method),
+		zap.String("grpc.code", status.Code(err).String()),
+		zap.Int64("stream.msgs_sent", s.sent.Load()),
+		zap.Int64("stream.msgs_received", s.received.Load()),
+		zap.Duration("stream.duration", duration),
+	)
+}
+
+// getSetRequestID looks for a request ID from incoming metadata, using the default options.
+func getSetRequestID(ctx context.Context) context.Context {
+	return newOptions(nil).getSetRequestID(ctx)
+}
+
 // getSetRequestID looks for a request ID from incoming metadata
 // If none exists, create it, add it to the logging dataset and set the Response ID
 // It also adds the Request ID to any new outgoing (downstream) requests.
-func getSetRequestID(ctx context.Context) context.Context {
+// If requested (and authorised), the log level of the request's context loggers is also overridden.
+func (o *options) getSetRequestID(ctx context.Context) context.Context {
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		s := ctxzap.Extract(ctx).Sugar()
-		var reqID string
-		xrID := md[RequestIDKey] // Check if we have a request ID. If not create one
-		if len(xrID) > 0 {
-			reqID = strings.Trim(xrID[0], " ")
+		// Check if we have a request ID. If not create one
+		reqID, key := o.incomingRequestID(md)
+		var fields []zap.Field
+		if reason := o.validation.Check(reqID); len(reason) > 0 { // Invalid Request ID, replace it
+			fields = append(fields, zap.String(ClientReqLogKey, SanitiseValue(reqID)))
+			reqID, key = "", ""
+			s.Debugf("Replacing invalid Request ID: %v", reason)
 		}
 		if len(reqID) == 0 { // No Request ID, create one
-			reqID = uuid.New().String()
-			md.Set(RequestIDKey, reqID)
+			reqID = o.newRequestID()
 			s.Debugf("Creating Request ID: %v", reqID)
-			ctx = metadata.NewIncomingContext(ctx, md) // Add the Request ID to the incoming metadata
 		}
-		ctxzap.AddFields(ctx, zap.String(ReqLogKey, reqID)) // Add Request ID to the logging
-		if span := oteltrace.SpanContextFromContext(ctx); span.IsSampled() {
-			ctxzap.AddFields(ctx, zap.String(TraceLogKey, span.TraceID().String())) // Add Trace ID to the logging
-			ctxzap.AddFields(ctx, zap.String(SpanLogKey, span.SpanID().String()))   // Add Span ID to the logging
+		out := md.Copy()
+		out.Set(o.requestHeader(), reqID)
+		if o.overwrite && key != o.requestHeader() {
+			ctx = metadata.NewIncomingContext(ctx, out.Copy()) // Add the Request ID to the incoming metadata
+		}
+		if o.logFields&LogRequestID != 0 {
+			fields = append([]zap.Field{zap.String(o.reqLogKey, reqID)}, fields...) // Add Request ID to the logging
+		}
+		if span := incomingTrace(ctx, md); span.IsValid() {
+			ctx = ContextWithTrace(ctx, span)
+			if span.IsSampled() || o.unsampledTraces {
+				if o.logFields&LogTraceID != 0 {
+					fields = append(fields, zap.String(o.traceLogKey, span.TraceID().String())) // Add Trace ID to the logging
+				}
+				if o.logFields&LogSpanID != 0 {
+					fields = append(fields, zap.String(o.spanLogKey, span.SpanID().String())) // Add Span ID to the logging
+				}
+				if o.logFields&LogTraceSampled != 0 {
+					fields = append(fields, zap.Bool(TraceSampledLogKey, span.IsSampled()))
+				}
+			}
+		}
+		fields = append(fields, o.metadataLogFields(ctx, md, out)...)
+		if lvl, ok := o.levelOverride.requested(ctx, md); ok { // Switch the context loggers to the requested level
+			ctx = applyLevelOverride(ctx, lvl)
+			fields = append(fields, zap.String(LevelOverrideLogKey, lvl.String()))
 		}
+		ctxzap.AddFields(ctx, fields...)
+		ctx = logger.WithFields(ctx, fields...)             // Make the fields available to logger.Ctx/SCtx
 		ctx = context.WithValue(ctx, requestIDKey{}, reqID) // Add Request ID to current context
-		ctx = metadata.NewOutgoingContext(ctx, md)          // Add the incoming metadata to any outgoing requests
+		ctx = metadata.NewOutgoingContext(ctx, out)         // Add the incoming metadata to any outgoing requests
+		o.setResponseID(ctx, s, reqID)
+	}
+	return ctx
+}
 
-		trailer := metadata.New(map[string]string{ResponseIDKey: reqID}) // Set the Response ID into trailer
-		if err := grpc.SetTrailer(ctx, trailer); err != nil {
-			s.Debugf("Warning: Unable to set response trailer '%v' %v: %v", ResponseIDKey, reqID, err)
+// setResponseID returns the Response ID to the client, in the configured header and/or trailer.
+func (o *options) setResponseID(ctx context.Context, s *zap.SugaredLogger, reqID string) {
+	respMD := metadata.New(map[string]string{o.responseHeader: reqID})
+	if o.responseInHeader() {
+		if err := grpc.SetHeader(ctx, respMD); err != nil {
+			s.Debugf("Warning: Unable to set response header '%v' %v: %v", o.responseHeader, reqID, err)
+		}
+	}
+	if o.responseInTrailer() {
+		if err := grpc.SetTrailer(ctx, respMD); err != nil {
+			s.Debugf("Warning: Unable to set response trailer '%v' %v: %v", o.responseHeader, reqID, err)
 		}
 	}
-	return ctx
 }
 
 // RequestIDFromContext retrieves the Request ID from context, if it exists.
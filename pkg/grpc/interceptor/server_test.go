@@ -27,6 +27,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
 	"github.com/stretchr/testify/assert"
 
 	"golang.org/x/net/context"
@@ -120,3 +121,15 @@ func TestCtxPropStreamServerNoReqID(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestGetSetRequestIDLoggerFields(t *testing.T) {
+	ctx := context.Background()
+	md := metadata.Pairs(RequestIDKey, "555555")
+	ctx = metadata.NewIncomingContext(ctx, md)
+	newCtx := getSetRequestID(ctx)
+	fields := logger.Fields(newCtx)
+	if assert.Len(t, fields, 1) {
+		assert.Equal(t, ReqLogKey, fields[0].Key)
+		assert.Equal(t, "555555", fields[0].String)
+	}
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// echoStreamSeen records what the echo stream handler could see in its stream context.
+type echoStreamSeen struct {
+	reqID       string
+	fields      []zap.Field
+	outgoingIDs []string
+}
+
+// newEchoServer starts a bufconn gRPC server with a bidirectional echo stream and a client streaming collector,
+// returning a client connection.
+// Any extra interceptors are chained after the context propagation interceptor.
+func newEchoServer(t *testing.T, seen *echoStreamSeen, extra ...grpc.StreamServerInterceptor) *grpc.ClientConn {
+	t.Helper()
+	desc := grpc.ServiceDesc{
+		ServiceName: "test.Echo",
+		HandlerType: (*interface{})(nil),
+		Streams: []grpc.StreamDesc{{
+			StreamName:    "Echo",
+			ServerStreams: true,
+			ClientStreams: true,
+			Handler: func(_ interface{}, stream grpc.ServerStream) error {
+				ctx := stream.Context()
+				seen.reqID = RequestIDFromContext(ctx)
+				seen.fields = logger.Fields(ctx)
+				md, _ := metadata.FromOutgoingContext(ctx)
+				seen.outgoingIDs = md.Get(RequestIDKey)
+				for {
+					msg := &wrapperspb.StringValue{}
+					if err := stream.RecvMsg(msg); err != nil {
+						if errors.Is(err, io.EOF) {
+							return nil
+						}
+						return err
+					}
+					if err := stream.SendMsg(msg); err != nil {
+						return err
+					}
+				}
+			},
+		}, {
+			StreamName:    "Collect",
+			ClientStreams: true,
+			Handler: func(_ interface{}, stream grpc.ServerStream) error {
+				var values []string
+				for {
+					msg := &wrapperspb.StringValue{}
+					if err := stream.RecvMsg(msg); err != nil {
+						if errors.Is(err, io.EOF) {
+							return stream.SendMsg(wrapperspb.String(strings.Join(values, ",")))
+						}
+						return err
+					}
+					values = append(values, msg.GetValue())
+				}
+			},
+		}},
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{ContextPropagationStreamServerInterceptor()}, extra...)...))
+	server.RegisterService(&desc, struct{}{})
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(ContextPropagationStreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestStreamContextPropagationBufconn(t *testing.T) {
+	logs := observeLogs(t)
+	var seen echoStreamSeen
+	conn := newEchoServer(t, &seen)
+	ctx := ContextWithRequestID(context.Background(), "stream-e2e-id")
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}, "/test.Echo/Echo")
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, value := range []string{"one", "two", "three"} {
+		assert.NoError(t, stream.SendMsg(wrapperspb.String(value)))
+	}
+	assert.NoError(t, stream.CloseSend())
+	var received []string
+	for {
+		msg := &wrapperspb.StringValue{}
+		if err = stream.RecvMsg(msg); err != nil {
+			assert.ErrorIs(t, err, io.EOF)
+			break
+		}
+		received = append(received, msg.GetValue())
+	}
+	assert.Equal(t, []string{"one", "two", "three"}, received)
+	assert.Equal(t, "stream-e2e-id", seen.reqID, "the handler should see the Request ID in the stream context")
+	assert.Equal(t, []string{"stream-e2e-id"}, seen.outgoingIDs, "the handler should see the outgoing metadata")
+	if assert.NotEmpty(t, seen.fields) {
+		assert.Equal(t, ReqLogKey, seen.fields[0].Key)
+		assert.Equal(t, "stream-e2e-id", seen.fields[0].String)
+	}
+	assert.Equal(t, []string{"stream-e2e-id"}, stream.Trailer().Get(ResponseIDKey))
+	assert.Equal(t, 0, logs.FilterMessage("Response ID does not match the Request ID").Len())
+
+	closed := logs.FilterMessage("Stream closed").All()
+	if assert.Len(t, closed, 1) {
+		fields := closed[0].ContextMap()
+		assert.Equal(t, "/test.Echo/Echo", fields[FullMethodLogKey])
+		assert.Equal(t, "OK", fields["grpc.code"])
+		assert.Equal(t, int64(3), fields["stream.msgs_sent"])
+		assert.Equal(t, int64(3), fields["stream.msgs_received"])
+		assert.Equal(t, "stream-e2e-id", fields[ReqLogKey])
+		assert.Contains(t, fields, "stream.duration")
+	}
+}
+
+func TestClientStreamingResponseIDBufconn(t *testing.T) {
+	logs := observeLogs(t)
+	var seen echoStreamSeen
+	conn := newEchoServer(t, &seen)
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return cc.NewStream(ctx, desc, method, opts...)
+	}
+	newStream := func(opts ...Option) grpc.ClientStream {
+		ctx := ContextWithRequestID(context.Background(), "collect-id")
+		stream, err := ContextPropagationStreamClientInterceptor(opts...)(ctx, &grpc.StreamDesc{ClientStreams: true}, conn, "/test.Echo/Collect", streamer)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		for _, value := range []string{"one", "two"} {
+			assert.NoError(t, stream.SendMsg(wrapperspb.String(value)))
+		}
+		assert.NoError(t, stream.CloseSend())
+		return stream
+	}
+	stream := newStream()
+	msg := &wrapperspb.StringValue{}
+	assert.NoError(t, stream.RecvMsg(msg)) // i.e. CloseAndRecv
+	assert.Equal(t, "one,two", msg.GetValue())
+	assert.Equal(t, []string{"collect-id"}, stream.Trailer().Get(ResponseIDKey))
+	assert.Equal(t, 0, logs.FilterMessage("No Response ID returned").Len())
+
+	// The Response ID is checked as soon as the single response is received, without another RecvMsg
+	stream = newStream(WithResponseIDHeader("x-other-response-id"))
+	assert.NoError(t, stream.RecvMsg(&wrapperspb.StringValue{}))
+	missing := logs.FilterMessage("No Response ID returned").All()
+	if assert.Len(t, missing, 1) {
+		assert.Equal(t, "/test.Echo/Collect", missing[0].ContextMap()[FullMethodLogKey])
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// Trace context metadata keys, used when there's no OpenTelemetry span in the context.
+const (
+	TraceParentKey     = "traceparent"   // W3C Trace Context parent
+	TraceStateKey      = "tracestate"    // W3C Trace Context vendor state
+	B3Key              = "b3"            // B3 single header
+	B3TraceIDKey       = "x-b3-traceid"  // B3 multi header trace ID
+	B3SpanIDKey        = "x-b3-spanid"   // B3 multi header span ID
+	B3SampledKey       = "x-b3-sampled"  // B3 multi header sampling decision
+	B3FlagsKey         = "x-b3-flags"    // B3 multi header debug flag
+	TraceSampledLogKey = "trace_sampled" // Log field recording whether the trace is sampled
+)
+
+type traceContextKey struct{} // Used for storing the request's trace context in a context
+
+// WithUnsampledTraces also logs the trace & span IDs of traces which aren't sampled (by default only sampled ones are logged).
+func WithUnsampledTraces() Option {
+	return func(o *options) {
+		o.unsampledTraces = true
+	}
+}
+
+// TraceFromContext returns the trace context of the request: the OpenTelemetry span, or the one parsed from the
+// W3C traceparent/tracestate or B3 headers by the server interceptors (or HTTP middleware). It is invalid if there is none.
+func TraceFromContext(ctx context.Context) oteltrace.SpanContext {
+	if sc, ok := ctx.Value(traceContextKey{}).(oteltrace.SpanContext); ok {
+		return sc
+	}
+	return oteltrace.SpanContextFromContext(ctx)
+}
+
+// ContextWithTrace returns a copy of the context holding the request's trace context (see TraceFromContext).
+func ContextWithTrace(ctx context.Context, sc oteltrace.SpanContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, sc)
+}
+
+// IncomingHTTPTrace returns the trace context of an HTTP request, from the OpenTelemetry span if there is one,
+// otherwise from the W3C traceparent/tracestate, B3 single or B3 multi headers (in that order).
+func IncomingHTTPTrace(ctx context.Context, header http.Header) oteltrace.SpanContext {
+	return traceFromHeaders(ctx, func(key string) string { return strings.TrimSpace(header.Get(key)) })
+}
+
+// incomingTrace returns the trace context of a gRPC request, reading the trace headers from the incoming metadata.
+func incomingTrace(ctx context.Context, md metadata.MD) oteltrace.SpanContext {
+	return traceFromHeaders(ctx, func(key string) string { return firstValue(md, key) })
+}
+
+// traceFromHeaders returns the trace context of the request, from the OpenTelemetry span if there is one,
+// otherwise from the W3C traceparent/tracestate, B3 single or B3 multi headers (in that order).
+func traceFromHeaders(ctx context.Context, header func(key string) string) oteltrace.SpanContext {
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		return sc
+	}
+	if sc, ok := parseTraceParent(header(TraceParentKey), header(TraceStateKey)); ok {
+		return sc
+	}
+	if sc, ok := parseB3Single(header(B3Key)); ok {
+		return sc
+	}
+	sc, _ := parseB3Multi(header(B3TraceIDKey), header(B3SpanIDKey), header(B3SampledKey), header(B3FlagsKey))
+	return sc
+}
+
+// parseTraceParent parses a W3C traceparent header (version-traceid-parentid-flags), along with its tracestate.
+func parseTraceParent(traceParent, traceState string) (oteltrace.SpanContext, bool) {
+	parts := strings.Split(traceParent, "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return oteltrace.SpanContext{}, false
+	}
+	if len(parts[3]) != 2 || !isHex(parts[0]) || !isHex(parts[3]) {
+		return oteltrace.SpanContext{}, false
+	}
+	cfg, ok := spanConfig(parts[1], parts[2])
+	if !ok {
+		return oteltrace.SpanContext{}, false
+	}
+	if flags := parts[3]; strings.ContainsAny(flags[1:], "13579bdf") { // The sampled flag is the least significant bit
+		cfg.TraceFlags = oteltrace.FlagsSampled
+	}
+	if state, err := oteltrace.ParseTraceState(traceState); err == nil {
+		cfg.TraceState = state // An invalid tracestate is ignored, as per the spec
+	}
+	return oteltrace.NewSpanContext(cfg), true
+}
+
+// parseB3Single parses a B3 single header: {TraceId}-{SpanId}[-{SamplingState}[-{ParentSpanId}]].
+func parseB3Single(b3 string) (oteltrace.SpanContext, bool) {
+	parts := strings.Split(b3, "-")
+	if len(parts) < 2 || len(parts) > 4 {
+		return oteltrace.SpanContext{}, false // Missing IDs (i.e. only a sampling decision)
+	}
+	var sampled string
+	if len(parts) > 2 {
+		sampled = parts[2]
+	}
+	return parseB3Multi(parts[0], parts[1], sampled, "")
+}
+
+// parseB3Multi parses the B3 multi headers: X-B3-TraceId, X-B3-SpanId, X-B3-Sampled and X-B3-Flags.
+func parseB3Multi(traceID, spanID, sampled, flags string) (oteltrace.SpanContext, bool) {
+	if len(traceID) == 16 { // 64 bit trace IDs are left padded to 128 bits
+		traceID = strings.Repeat("0", 16) + traceID
+	}
+	cfg, ok := spanConfig(strings.ToLower(traceID), strings.ToLower(spanID))
+	if !ok {
+		return oteltrace.SpanContext{}, false
+	}
+	switch strings.ToLower(sampled) {
+	case "1", "d", "true":
+		cfg.TraceFlags = oteltrace.FlagsSampled
+	}
+	if flags == "1" { // Debug implies sampled
+		cfg.TraceFlags = oteltrace.FlagsSampled
+	}
+	return oteltrace.NewSpanContext(cfg), true
+}
+
+// spanConfig returns the config of a remote span with the given (lowercase hex) trace & span IDs, if they're valid.
+func spanConfig(traceID, spanID string) (oteltrace.SpanContextConfig, bool) {
+	tid, err := oteltrace.TraceIDFromHex(traceID)
+	if err != nil {
+		return oteltrace.SpanContextConfig{}, false
+	}
+	sid, err := oteltrace.SpanIDFromHex(spanID)
+	if err != nil {
+		return oteltrace.SpanContextConfig{}, false
+	}
+	return oteltrace.SpanContextConfig{TraceID: tid, SpanID: sid, Remote: true}, true
+}
+
+// firstValue returns the first (trimmed) metadata value for the given key.
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+	return ""
+}
+
+// isHex reports whether the string only contains lowercase hex characters.
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestIncomingTrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		traceID string
+		spanID  string
+		sampled bool
+		state   string
+	}{
+		{name: "traceparent sampled", md: metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-01", TraceStateKey, "vendor=value"),
+			traceID: testTraceID, spanID: testSpanID, sampled: true, state: "vendor=value"},
+		{name: "traceparent unsampled", md: metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-00"),
+			traceID: testTraceID, spanID: testSpanID},
+		{name: "traceparent future version", md: metadata.Pairs(TraceParentKey, "01-"+testTraceID+"-"+testSpanID+"-03-extra"),
+			traceID: testTraceID, spanID: testSpanID, sampled: true},
+		{name: "traceparent invalid tracestate", md: metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-01", TraceStateKey, "==="),
+			traceID: testTraceID, spanID: testSpanID, sampled: true},
+		{name: "traceparent zero trace id", md: metadata.Pairs(TraceParentKey, "00-00000000000000000000000000000000-"+testSpanID+"-01")},
+		{name: "traceparent uppercase", md: metadata.Pairs(TraceParentKey, "00-4BF92F3577B34DA6A3CE929D0E0E4736-"+testSpanID+"-01")},
+		{name: "traceparent version ff", md: metadata.Pairs(TraceParentKey, "ff-"+testTraceID+"-"+testSpanID+"-01")},
+		{name: "traceparent extra fields", md: metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-01-extra")},
+		{name: "b3 single", md: metadata.Pairs(B3Key, testTraceID+"-"+testSpanID+"-1-05e3ac9a4f6e3b90"),
+			traceID: testTraceID, spanID: testSpanID, sampled: true},
+		{name: "b3 single debug 64 bit", md: metadata.Pairs(B3Key, "a3ce929d0e0e4736-"+testSpanID+"-d"),
+			traceID: "0000000000000000a3ce929d0e0e4736", spanID: testSpanID, sampled: true},
+		{name: "b3 single deferred", md: metadata.Pairs(B3Key, testTraceID+"-"+testSpanID), traceID: testTraceID, spanID: testSpanID},
+		{name: "b3 single sampling only", md: metadata.Pairs(B3Key, "1")},
+		{name: "b3 multi", md: metadata.Pairs(B3TraceIDKey, testTraceID, B3SpanIDKey, testSpanID, B3SampledKey, "1"),
+			traceID: testTraceID, spanID: testSpanID, sampled: true},
+		{name: "b3 multi debug", md: metadata.Pairs(B3TraceIDKey, testTraceID, B3SpanIDKey, testSpanID, B3FlagsKey, "1"),
+			traceID: testTraceID, spanID: testSpanID, sampled: true},
+		{name: "b3 multi unsampled", md: metadata.Pairs(B3TraceIDKey, testTraceID, B3SpanIDKey, testSpanID, B3SampledKey, "0"),
+			traceID: testTraceID, spanID: testSpanID},
+		{name: "b3 multi missing span", md: metadata.Pairs(B3TraceIDKey, testTraceID)},
+		{name: "traceparent preferred", md: metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-00", B3Key, "a3ce929d0e0e4736-a3ce929d0e0e4736-1"),
+			traceID: testTraceID, spanID: testSpanID},
+		{name: "none", md: metadata.Pairs("other", "value")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := incomingTrace(context.Background(), tt.md)
+			if len(tt.traceID) == 0 {
+				assert.False(t, sc.IsValid(), "no trace context should be found")
+				return
+			}
+			if assert.True(t, sc.IsValid()) {
+				assert.Equal(t, tt.traceID, sc.TraceID().String())
+				assert.Equal(t, tt.spanID, sc.SpanID().String())
+				assert.Equal(t, tt.sampled, sc.IsSampled())
+				assert.Equal(t, tt.state, sc.TraceState().String())
+				assert.True(t, sc.IsRemote())
+			}
+		})
+	}
+}
+
+func TestIncomingTraceOTelPreferred(t *testing.T) {
+	tid, _ := oteltrace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	sid, _ := oteltrace.SpanIDFromHex("b7ad6b7169203331")
+	span := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: tid, SpanID: sid})
+	ctx := oteltrace.ContextWithSpanContext(context.Background(), span)
+	sc := incomingTrace(ctx, metadata.Pairs(TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-01"))
+	assert.Equal(t, tid, sc.TraceID(), "the OpenTelemetry span should take precedence")
+}
+
+func TestIncomingHTTPTrace(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-B3-TraceId", testTraceID)
+	header.Set("X-B3-SpanId", testSpanID)
+	header.Set("X-B3-Sampled", "1")
+	sc := IncomingHTTPTrace(context.Background(), header)
+	if assert.True(t, sc.IsValid()) {
+		assert.Equal(t, testTraceID, sc.TraceID().String())
+		assert.True(t, sc.IsSampled())
+	}
+	header.Set("Traceparent", " 00-"+testTraceID+"-"+testSpanID+"-00 ")
+	sc = IncomingHTTPTrace(context.Background(), header)
+	assert.False(t, sc.IsSampled(), "traceparent should take precedence")
+	assert.Equal(t, testTraceID, TraceFromContext(ContextWithTrace(context.Background(), sc)).TraceID().String())
+	assert.False(t, IncomingHTTPTrace(context.Background(), http.Header{}).IsValid())
+}
+
+// traceFields returns the logging fields added by the server interceptor for the given incoming metadata.
+func traceFields(md metadata.MD, opts ...Option) (context.Context, map[string]interface{}) {
+	newCtx := newOptions(opts).getSetRequestID(metadata.NewIncomingContext(context.Background(), md))
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range logger.Fields(newCtx) {
+		f.AddTo(enc)
+	}
+	return newCtx, enc.Fields
+}
+
+func TestTraceHeaderLogging(t *testing.T) {
+	sampled := metadata.Pairs(RequestIDKey, "trace-req", TraceParentKey, "00-"+testTraceID+"-"+testSpanID+"-01")
+	ctx, fields := traceFields(sampled)
+	assert.Equal(t, testTraceID, fields[TraceLogKey])
+	assert.Equal(t, testSpanID, fields[SpanLogKey])
+	assert.Equal(t, true, fields[TraceSampledLogKey])
+	assert.Equal(t, testTraceID, TraceFromContext(ctx).TraceID().String())
+
+	unsampled := metadata.Pairs(RequestIDKey, "trace-req", B3Key, testTraceID+"-"+testSpanID+"-0")
+	ctx, fields = traceFields(unsampled)
+	assert.NotContains(t, fields, TraceLogKey, "unsampled traces should not be logged by default")
+	assert.NotContains(t, fields, TraceSampledLogKey)
+	assert.True(t, TraceFromContext(ctx).IsValid(), "the trace context should still be available")
+
+	_, fields = traceFields(unsampled, WithUnsampledTraces())
+	assert.Equal(t, testTraceID, fields[TraceLogKey])
+	assert.Equal(t, testSpanID, fields[SpanLogKey])
+	assert.Equal(t, false, fields[TraceSampledLogKey])
+
+	_, fields = traceFields(sampled, WithLogFields(LogRequestID|LogTraceID))
+	assert.Equal(t, testTraceID, fields[TraceLogKey])
+	assert.NotContains(t, fields, SpanLogKey)
+	assert.NotContains(t, fields, TraceSampledLogKey)
+
+	_, fields = traceFields(metadata.Pairs(RequestIDKey, "trace-req"))
+	assert.Equal(t, map[string]interface{}{ReqLogKey: "trace-req"}, fields)
+	assert.False(t, TraceFromContext(context.Background()).IsValid())
+}
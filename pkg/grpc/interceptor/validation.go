@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ClientReqLogKey          = "client_req_id" // Log field holding the (sanitised) invalid Request ID sent by the client
+	DefaultRequestIDMaxLen   = 128             // Default maximum Request ID length
+	maxLogValueLength        = 256             // Maximum length of a logged untrusted value
+	sanitisedReplacementChar = '?'             // Replacement for unprintable characters in a logged untrusted value
+)
+
+// RequestIDFormat is a format incoming Request IDs can be required to match.
+type RequestIDFormat int
+
+// Supported Request ID formats.
+const (
+	RequestIDFormatAny  RequestIDFormat = iota // No specific format (default)
+	RequestIDFormatUUID                        // A canonical (8-4-4-4-12 hex) UUID
+	RequestIDFormatULID                        // A 26 character ULID
+)
+
+var (
+	uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	ulidPattern = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+
+	replacedRequestIDs atomic.Uint64 // Number of invalid Request IDs replaced
+	rejectedRequestIDs atomic.Uint64 // Number of requests rejected due to an invalid Request ID
+)
+
+// RequestIDValidationStats holds the number of invalid incoming Request IDs seen by the server interceptors/HTTP middleware.
+type RequestIDValidationStats struct {
+	Replaced uint64 // Invalid Request IDs replaced with a new one
+	Rejected uint64 // Requests rejected with codes.InvalidArgument (or HTTP 400 Bad Request)
+}
+
+// InvalidRequestIDStats returns the number of invalid incoming Request IDs replaced/rejected (since startup),
+// by both the gRPC server interceptors and the HTTP middleware.
+func InvalidRequestIDStats() RequestIDValidationStats {
+	return RequestIDValidationStats{Replaced: replacedRequestIDs.Load(), Rejected: rejectedRequestIDs.Load()}
+}
+
+// RequestIDValidation holds the incoming Request ID validation settings, shared by the gRPC server interceptors
+// and the HTTP middleware. By default (DefaultRequestIDValidation), IDs must be at most 128 printable (non-space) ASCII characters.
+type RequestIDValidation struct {
+	MaxLength int             // Maximum length (0 for unlimited)
+	Charset   string          // Allowed characters (empty for printable ASCII)
+	Pattern   *regexp.Regexp  // Pattern the ID has to match
+	Format    RequestIDFormat // Format the ID has to match
+	Reject    bool            // Reject (rather than replace) invalid IDs
+}
+
+// DefaultRequestIDValidation returns the default Request ID validation settings.
+func DefaultRequestIDValidation() RequestIDValidation {
+	return RequestIDValidation{MaxLength: DefaultRequestIDMaxLen}
+}
+
+// WithRequestIDMaxLength sets the maximum length of incoming Request IDs (default: 128, 0 for unlimited).
+func WithRequestIDMaxLength(maxLength int) Option {
+	return func(o *options) {
+		o.validation.MaxLength = max(maxLength, 0)
+	}
+}
+
+// WithRequestIDCharset restricts incoming Request IDs to the given characters (default: printable non-space ASCII).
+func WithRequestIDCharset(charset string) Option {
+	return func(o *options) {
+		o.validation.Charset = charset
+	}
+}
+
+// WithRequestIDPattern requires incoming Request IDs to match the given regular expression.
+func WithRequestIDPattern(pattern *regexp.Regexp) Option {
+	return func(o *options) {
+		o.validation.Pattern = pattern
+	}
+}
+
+// WithRequestIDFormat requires incoming Request IDs to be in the given format (i.e. RequestIDFormatUUID).
+func WithRequestIDFormat(format RequestIDFormat) Option {
+	return func(o *options) {
+		o.validation.Format = format
+	}
+}
+
+// WithRejectInvalidRequestID rejects requests with an invalid Request ID (codes.InvalidArgument),
+// rather than replacing the ID with a new one.
+func WithRejectInvalidRequestID() Option {
+	return func(o *options) {
+		o.validation.Reject = true
+	}
+}
+
+// Validate returns why the Request ID is invalid, or an empty string if it is valid.
+func (v *RequestIDValidation) Validate(reqID string) string {
+	if v.MaxLength > 0 && len(reqID) > v.MaxLength {
+		return fmt.Sprintf("longer than %v characters", v.MaxLength)
+	}
+	for _, r := range reqID {
+		if len(v.Charset) > 0 {
+			if !strings.ContainsRune(v.Charset, r) {
+				return fmt.Sprintf("contains a disallowed character %q", r)
+			}
+		} else if r <= ' ' || r > '~' {
+			return fmt.Sprintf("contains an invalid character %q", r)
+		}
+	}
+	switch v.Format {
+	case RequestIDFormatUUID:
+		if !uuidPattern.MatchString(reqID) {
+			return "not a UUID"
+		}
+	case RequestIDFormatULID:
+		if !ulidPattern.MatchString(reqID) {
+			return "not a ULID"
+		}
+	case RequestIDFormatAny:
+	}
+	if v.Pattern != nil && !v.Pattern.MatchString(reqID) {
+		return fmt.Sprintf("does not match %v", v.Pattern)
+	}
+	return ""
+}
+
+// Check validates an incoming Request ID, counting it as rejected (if Reject is set) or replaced if it is invalid
+// (see InvalidRequestIDStats). It returns why the ID is invalid, or an empty string if it is valid (or empty).
+func (v *RequestIDValidation) Check(reqID string) string {
+	if len(reqID) == 0 {
+		return ""
+	}
+	reason := v.Validate(reqID)
+	if len(reason) > 0 {
+		if v.Reject {
+			rejectedRequestIDs.Add(1)
+		} else {
+			replacedRequestIDs.Add(1)
+		}
+	}
+	return reason
+}
+
+// rejectInvalidRequestID returns an InvalidArgument error if rejection is enabled and the incoming Request ID is invalid.
+func (o *options) rejectInvalidRequestID(ctx context.Context) error {
+	if !o.validation.Reject {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	reqID, key := o.incomingRequestID(md)
+	if reason := o.validation.Check(reqID); len(reason) > 0 {
+		ctxzap.Extract(ctx).Sugar().Debugf("Rejecting invalid Request ID (%v): %v", reason, SanitiseValue(reqID))
+		return status.Errorf(codes.InvalidArgument, "invalid %v: %v", key, reason)
+	}
+	return nil
+}
+
+// SanitiseValue makes an untrusted value (i.e. an invalid Request ID) safe to log,
+// replacing unprintable characters and truncating it.
+func SanitiseValue(value string) string {
+	if len(value) > maxLogValueLength {
+		value = value[:maxLogValueLength]
+		for len(value) > 0 && !utf8.ValidString(value) { // Don't split a multibyte character
+			value = value[:len(value)-1]
+		}
+	}
+	return strings.Map(func(r rune) rune {
+		if r < ' ' || r > '~' {
+			return sanitisedReplacementChar
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package interceptor
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestIDValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []Option
+		id    string
+		valid bool
+	}{
+		{name: "uuid", id: "b0c3a1d2-4e5f-4a6b-8c7d-9e0f1a2b3c4d", valid: true},
+		{name: "custom", id: "job:42/retry=1", valid: true},
+		{name: "newline", id: "abc\ninjected", valid: false},
+		{name: "space", id: "abc def", valid: false},
+		{name: "non ascii", id: "abcé", valid: false},
+		{name: "too long", id: strings.Repeat("a", DefaultRequestIDMaxLen+1), valid: false},
+		{name: "max length", id: strings.Repeat("a", DefaultRequestIDMaxLen), valid: true},
+		{name: "custom max length", opts: []Option{WithRequestIDMaxLength(8)}, id: "123456789", valid: false},
+		{name: "unlimited length", opts: []Option{WithRequestIDMaxLength(0)}, id: strings.Repeat("a", 4096), valid: true},
+		{name: "charset", opts: []Option{WithRequestIDCharset("0123456789abcdef-")}, id: "abc-123", valid: true},
+		{name: "charset invalid", opts: []Option{WithRequestIDCharset("0123456789abcdef-")}, id: "xyz", valid: false},
+		{name: "pattern", opts: []Option{WithRequestIDPattern(regexp.MustCompile(`^req-\d+$`))}, id: "req-123", valid: true},
+		{name: "pattern invalid", opts: []Option{WithRequestIDPattern(regexp.MustCompile(`^req-\d+$`))}, id: "req-abc", valid: false},
+		{name: "uuid format", opts: []Option{WithRequestIDFormat(RequestIDFormatUUID)}, id: "B0C3A1D2-4E5F-4A6B-8C7D-9E0F1A2B3C4D", valid: true},
+		{name: "uuid format invalid", opts: []Option{WithRequestIDFormat(RequestIDFormatUUID)}, id: "{b0c3a1d2-4e5f-4a6b-8c7d-9e0f1a2b3c4d}", valid: false},
+		{name: "ulid format", opts: []Option{WithRequestIDFormat(RequestIDFormatULID)}, id: "01ARZ3NDEKTSV4RRFFQ69G5FAV", valid: true},
+		{name: "ulid format invalid", opts: []Option{WithRequestIDFormat(RequestIDFormatULID)}, id: "01ARZ3NDEKTSV4RRFFQ69G5FAU", valid: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := newOptions(tt.opts).validation.Validate(tt.id)
+			assert.Equal(t, tt.valid, len(reason) == 0, "unexpected validation result: %v", reason)
+		})
+	}
+}
+
+func TestInvalidRequestIDReplaced(t *testing.T) {
+	before := InvalidRequestIDStats()
+	ctx, stream := newTransportContext(RequestIDKey, "bad\r\nid\x00"+strings.Repeat("x", 1000))
+	newCtx := getSetRequestID(ctx)
+
+	reqID := RequestIDFromContext(newCtx)
+	assert.Regexp(t, uuidPattern, reqID, "the invalid ID should be replaced with a new one")
+	fields := fieldMap(newCtx)
+	assert.Equal(t, reqID, fields[ReqLogKey])
+	assert.Len(t, fields[ClientReqLogKey], maxLogValueLength)
+	assert.True(t, strings.HasPrefix(fields[ClientReqLogKey], "bad??id?x"), "the original ID should be sanitised: %v", fields[ClientReqLogKey])
+	in, _ := metadata.FromIncomingContext(newCtx)
+	assert.Equal(t, []string{reqID}, in.Get(RequestIDKey))
+	assert.Equal(t, []string{reqID}, stream.trailer.Get(ResponseIDKey))
+	assert.Equal(t, before.Replaced+1, InvalidRequestIDStats().Replaced)
+	assert.Equal(t, before.Rejected, InvalidRequestIDStats().Rejected)
+}
+
+func TestInvalidRequestIDRejected(t *testing.T) {
+	before := InvalidRequestIDStats()
+	opts := []Option{WithRejectInvalidRequestID(), WithRequestIDFormat(RequestIDFormatUUID)}
+	called := false
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "not-a-uuid"))
+	_, err := ContextPropagationUnaryServerInterceptor(opts...)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "not a UUID")
+	assert.False(t, called, "the handler should not be called")
+
+	stream := &testServerStream{ctx: ctx}
+	err = ContextPropagationStreamServerInterceptor(opts...)(nil, stream, &grpc.StreamServerInfo{}, streamHandler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, before.Rejected+2, InvalidRequestIDStats().Rejected)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value"))
+	_, err = ContextPropagationUnaryServerInterceptor(opts...)(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err, "a missing ID should still be generated")
+	assert.True(t, called)
+	assert.Equal(t, before.Replaced, InvalidRequestIDStats().Replaced)
+}
+
+func TestSanitiseRequestID(t *testing.T) {
+	assert.Equal(t, "a?b?c", SanitiseValue("a\nb\x1bc"))
+	assert.Equal(t, "caf?", SanitiseValue("café"))
+	long := strings.Repeat("a", maxLogValueLength-1) + "é"
+	assert.Equal(t, strings.Repeat("a", maxLogValueLength-1), SanitiseValue(long), "multibyte characters should not be split")
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Transport is an http.RoundTripper which makes sure every outgoing request carries a Request ID and trace context.
+// The ID is taken from the request header, the context (interceptor.RequestIDFromContext) or generated, in that order.
+// The W3C traceparent/tracestate headers are added from the trace context (interceptor.TraceFromContext),
+// and any mismatching Response ID is logged.
+type Transport struct {
+	Base http.RoundTripper // Transport used to send the requests (default: http.DefaultTransport)
+	opts *options
+}
+
+// NewTransport returns a Transport wrapping the given base transport (http.DefaultTransport if nil).
+func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
+	return &Transport{Base: base, opts: newOptions(opts)}
+}
+
+// RoundTrip sends the request, adding the Request ID & trace context headers if they're missing.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	o := t.opts
+	if o == nil {
+		o = newOptions(nil)
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	ctx := req.Context()
+	reqID := strings.TrimSpace(req.Header.Get(o.requestHeader))
+	span := interceptor.TraceFromContext(ctx)
+	addTrace := span.IsValid() && len(req.Header.Get(interceptor.TraceParentKey)) == 0
+	if len(reqID) == 0 || addTrace {
+		req = req.Clone(ctx) // A RoundTripper must not modify the original request
+		if len(reqID) == 0 {
+			reqID = interceptor.RequestIDFromContext(ctx)
+			if len(reqID) == 0 { // No Request ID, create one
+				reqID = o.idGenerator.NewID()
+				logger.Ctx(ctx).Debug("Creating outgoing Request ID", zap.String(interceptor.ReqLogKey, reqID))
+			}
+			req.Header.Set(o.requestHeader, reqID)
+		}
+		if addTrace {
+			req.Header.Set(interceptor.TraceParentKey, fmt.Sprintf("00-%v-%v-%v", span.TraceID(), span.SpanID(), span.TraceFlags()))
+			if state := span.TraceState().String(); len(state) > 0 {
+				req.Header.Set(interceptor.TraceStateKey, state)
+			}
+		}
+	}
+	resp, err := base.RoundTrip(req)
+	if err == nil {
+		if respID := strings.TrimSpace(resp.Header.Get(o.responseHeader)); len(respID) > 0 && respID != reqID {
+			logger.Ctx(ctx).Warn("Response ID does not match the Request ID",
+				zap.String("http.url", req.URL.Redacted()), zap.String("request_id", reqID), zap.String("response_id", respID))
+		}
+	}
+	return resp, err
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+)
+
+// newEchoServer starts a test server (wrapped in the middleware) which returns the headers it received.
+func newEchoServer(t *testing.T, respID string) (*httptest.Server, *http.Header) {
+	t.Helper()
+	got := &http.Header{}
+	srv := httptest.NewServer(Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*got = r.Header.Clone()
+		if len(respID) > 0 {
+			w.Header().Set(ResponseIDHeader, respID) // Override the middleware's Response ID
+		}
+	})))
+	t.Cleanup(srv.Close)
+	return srv, got
+}
+
+// get sends a GET request using the transport, returning the original request.
+func get(t *testing.T, ctx context.Context, transport http.RoundTripper, url string, header http.Header) *http.Request {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if !assert.NoError(t, err) {
+		return req
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+	}
+	return req
+}
+
+func TestTransportRequestIDFromContext(t *testing.T) {
+	logs := observeLogs(t)
+	srv, got := newEchoServer(t, "")
+	ctx := interceptor.ContextWithRequestID(context.Background(), "ctx-id")
+	req := get(t, ctx, NewTransport(nil), srv.URL, nil)
+	assert.Equal(t, "ctx-id", got.Get("X-Request-Id"))
+	assert.Empty(t, req.Header.Get("X-Request-Id"), "the original request should not be modified")
+	assert.Empty(t, got.Get(interceptor.TraceParentKey))
+	assert.Equal(t, 0, logs.FilterMessage("Response ID does not match the Request ID").Len())
+}
+
+func TestTransportGeneratesRequestID(t *testing.T) {
+	observeLogs(t)
+	srv, got := newEchoServer(t, "")
+	gen := interceptor.IDGeneratorFunc(func() string { return "new-id" })
+	get(t, context.Background(), NewTransport(http.DefaultTransport, WithIDGenerator(gen)), srv.URL, nil)
+	assert.Equal(t, "new-id", got.Get("X-Request-Id"))
+
+	get(t, context.Background(), NewTransport(nil), srv.URL, http.Header{"X-Request-Id": {"header-id"}})
+	assert.Equal(t, "header-id", got.Get("X-Request-Id"), "an existing header should be kept")
+
+	get(t, context.Background(), &Transport{}, srv.URL, nil)
+	assert.Len(t, got.Get("X-Request-Id"), 36, "the zero Transport should use the defaults")
+}
+
+func TestTransportTraceParent(t *testing.T) {
+	observeLogs(t)
+	srv, got := newEchoServer(t, "")
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	tid, _ := oteltrace.TraceIDFromHex(traceID)
+	sid, _ := oteltrace.SpanIDFromHex("00f067aa0ba902b7")
+	state, _ := oteltrace.ParseTraceState("vendor=value")
+	span := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: tid, SpanID: sid, TraceFlags: oteltrace.FlagsSampled, TraceState: state})
+	get(t, oteltrace.ContextWithSpanContext(context.Background(), span), NewTransport(nil), srv.URL, nil)
+	assert.Equal(t, "00-"+traceID+"-00f067aa0ba902b7-01", got.Get(interceptor.TraceParentKey))
+	assert.Equal(t, "vendor=value", got.Get(interceptor.TraceStateKey))
+
+	// The trace context parsed by the gRPC server interceptor is also propagated
+	md := metadata.Pairs(interceptor.RequestIDKey, "grpc-id", interceptor.TraceParentKey, "00-"+traceID+"-00f067aa0ba902b7-00")
+	var ctx context.Context
+	_, _ = interceptor.ContextPropagationUnaryServerInterceptor()(metadata.NewIncomingContext(context.Background(), md), nil, nil,
+		func(c context.Context, _ interface{}) (interface{}, error) {
+			ctx = c
+			return nil, nil
+		})
+	get(t, ctx, NewTransport(nil), srv.URL, nil)
+	assert.Equal(t, "grpc-id", got.Get("X-Request-Id"))
+	assert.Equal(t, "00-"+traceID+"-00f067aa0ba902b7-00", got.Get(interceptor.TraceParentKey))
+}
+
+func TestTransportResponseIDMismatch(t *testing.T) {
+	logs := observeLogs(t)
+	srv, _ := newEchoServer(t, "other-id")
+	ctx := interceptor.ContextWithRequestID(context.Background(), "mine")
+	get(t, ctx, NewTransport(nil), srv.URL, nil)
+	mismatch := logs.FilterMessage("Response ID does not match the Request ID").All()
+	if assert.Len(t, mismatch, 1) {
+		fields := mismatch[0].ContextMap()
+		assert.Equal(t, "mine", fields["request_id"])
+		assert.Equal(t, "other-id", fields["response_id"])
+	}
+}
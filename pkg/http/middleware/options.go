@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+)
+
+// ResponseIDHeader is the default response header carrying the Response ID.
+const ResponseIDHeader = "X-Response-Id"
+
+// Option configures the behaviour of the HTTP middleware and transport.
+type Option func(*options)
+
+// options holds the settings supplied to the HTTP middleware and transport.
+type options struct {
+	requestHeader   string                          // Request ID header name
+	responseHeader  string                          // Response ID header name
+	idGenerator     interceptor.IDGenerator         // Creates new Request IDs
+	validation      interceptor.RequestIDValidation // Incoming Request ID validation settings
+	unsampledTraces bool                            // Log the trace & span IDs of unsampled traces
+}
+
+// WithRequestIDHeader sets the header carrying the Request ID (default: X-Request-Id).
+func WithRequestIDHeader(name string) Option {
+	return func(o *options) {
+		if len(name) > 0 {
+			o.requestHeader = http.CanonicalHeaderKey(name)
+		}
+	}
+}
+
+// WithResponseIDHeader sets the header used to return (server) or check (client) the Response ID (default: X-Response-Id).
+func WithResponseIDHeader(name string) Option {
+	return func(o *options) {
+		if len(name) > 0 {
+			o.responseHeader = http.CanonicalHeaderKey(name)
+		}
+	}
+}
+
+// WithIDGenerator sets the generator used to create new Request IDs (default: interceptor.UUIDv4Generator).
+func WithIDGenerator(gen interceptor.IDGenerator) Option {
+	return func(o *options) {
+		if gen != nil {
+			o.idGenerator = gen
+		}
+	}
+}
+
+// WithRequestIDValidation sets the validation of incoming Request IDs, as used by the gRPC server interceptors
+// (default: interceptor.DefaultRequestIDValidation). Invalid IDs are replaced with a new one,
+// or the request is rejected with 400 Bad Request if Reject is set.
+func WithRequestIDValidation(validation interceptor.RequestIDValidation) Option {
+	return func(o *options) {
+		o.validation = validation
+	}
+}
+
+// WithUnsampledTraces also logs the trace & span IDs of traces which aren't sampled (by default only sampled ones are logged).
+func WithUnsampledTraces() Option {
+	return func(o *options) {
+		o.unsampledTraces = true
+	}
+}
+
+// newOptions applies the supplied options on top of the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{
+		requestHeader:  http.CanonicalHeaderKey(interceptor.RequestIDKey),
+		responseHeader: ResponseIDHeader,
+		idGenerator:    interceptor.UUIDv4Generator(),
+		validation:     interceptor.DefaultRequestIDValidation(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+// Package middleware provides net/http equivalents of the gRPC context propagation interceptors:
+// server middleware to capture/set the request/response ID (adding it to the zap logging context),
+// and a client transport to propagate it (and the trace context) on outgoing requests.
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Middleware returns HTTP middleware which checks the incoming request for a Request ID, creating one if it's missing (or invalid).
+// Invalid IDs are validated as by the gRPC server interceptors (see WithRequestIDValidation).
+// The ID is returned in the X-Response-Id header, made available via interceptor.RequestIDFromContext and added to
+// the logging fields of a request scoped logger (logger.Ctx/SCtx and ctxzap.Extract).
+// The trace context (OpenTelemetry span, W3C traceparent/tracestate or B3 headers) is made available via
+// interceptor.TraceFromContext, with the trace & span IDs of sampled traces added to the logging fields.
+func Middleware(opts ...Option) func(http.Handler) http.Handler {
+	o := newOptions(opts)
+	return func(next http.Handler) http.Handler {
+		return o.handler(next)
+	}
+}
+
+// Handler wraps the given handler with the Request ID middleware (see Middleware).
+func Handler(next http.Handler, opts ...Option) http.Handler {
+	return newOptions(opts).handler(next)
+}
+
+// handler returns the Request ID middleware handler.
+func (o *options) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		reqID := strings.TrimSpace(r.Header.Get(o.requestHeader))
+		if reason := o.validation.Check(reqID); len(reason) > 0 {
+			ctx = logger.WithFields(ctx, zap.String(interceptor.ClientReqLogKey, interceptor.SanitiseValue(reqID)))
+			if o.validation.Reject {
+				logger.Ctx(ctx).Debug("Rejecting invalid Request ID", zap.String("reason", reason))
+				http.Error(w, fmt.Sprintf("invalid %v: %v", o.requestHeader, reason), http.StatusBadRequest)
+				return
+			}
+			logger.Ctx(ctx).Debug("Replacing invalid Request ID", zap.String("reason", reason)) // Invalid Request ID, replace it
+			reqID = ""
+		}
+		if len(reqID) == 0 { // No Request ID, create one
+			reqID = o.idGenerator.NewID()
+			logger.Ctx(ctx).Debug("Creating Request ID", zap.String(interceptor.ReqLogKey, reqID))
+		}
+		ctx = interceptor.ContextWithRequestID(ctx, reqID)
+		if span := interceptor.IncomingHTTPTrace(ctx, r.Header); span.IsValid() {
+			ctx = interceptor.ContextWithTrace(ctx, span)
+			if span.IsSampled() || o.unsampledTraces {
+				ctx = logger.WithFields(ctx,
+					zap.String(interceptor.TraceLogKey, span.TraceID().String()),
+					zap.String(interceptor.SpanLogKey, span.SpanID().String()),
+					zap.Bool(interceptor.TraceSampledLogKey, span.IsSampled()),
+				)
+			}
+		}
+		ctx = ctxzap.ToContext(ctx, logger.Ctx(ctx)) // Request scoped logger, for ctxzap.Extract
+
+		r = r.WithContext(ctx)
+		r.Header.Set(o.requestHeader, reqID) // Make the ID available to handlers reading the header
+		w.Header().Set(o.responseHeader, reqID)
+		next.ServeHTTP(w, r)
+	})
+}
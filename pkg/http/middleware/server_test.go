@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/scanoss/zap-logging-helper/pkg/grpc/interceptor"
+	"github.com/scanoss/zap-logging-helper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeLogs replaces the global logger with an observer for the duration of the test.
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	t.Helper()
+	core, logs := observer.New(zap.DebugLevel)
+	logger.Set(zap.New(core))
+	t.Cleanup(func() { logger.Set(nil) })
+	return logs
+}
+
+// handlerSeen records what the wrapped handler could see.
+type handlerSeen struct {
+	reqID  string
+	header string
+}
+
+// newTestHandler returns a handler which logs via the request context loggers and records the Request ID.
+func newTestHandler(seen *handlerSeen) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen.reqID = interceptor.RequestIDFromContext(r.Context())
+		seen.header = r.Header.Get(interceptor.RequestIDKey)
+		logger.Ctx(r.Context()).Info("ctx logger")
+		ctxzap.Extract(r.Context()).Info("ctxzap logger")
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func TestMiddlewareExistingRequestID(t *testing.T) {
+	logs := observeLogs(t)
+	var seen handlerSeen
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", " http-id ")
+	rec := httptest.NewRecorder()
+	Middleware()(newTestHandler(&seen)).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "http-id", rec.Header().Get(ResponseIDHeader))
+	assert.Equal(t, "http-id", seen.reqID, "the ID should be available via RequestIDFromContext")
+	assert.Equal(t, "http-id", seen.header)
+	for _, msg := range []string{"ctx logger", "ctxzap logger"} {
+		entries := logs.FilterMessage(msg).All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, "http-id", entries[0].ContextMap()[interceptor.ReqLogKey], "%v should include the Request ID", msg)
+		}
+	}
+}
+
+func TestMiddlewareGeneratesRequestID(t *testing.T) {
+	observeLogs(t)
+	var seen handlerSeen
+	rec := httptest.NewRecorder()
+	gen := interceptor.IDGeneratorFunc(func() string { return "generated-id" })
+	Handler(newTestHandler(&seen), WithIDGenerator(gen), WithResponseIDHeader("x-correlation-id")).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, "generated-id", seen.reqID)
+	assert.Equal(t, "generated-id", rec.Header().Get("X-Correlation-Id"))
+	assert.Empty(t, rec.Header().Get(ResponseIDHeader))
+}
+
+func TestMiddlewareInvalidRequestID(t *testing.T) {
+	logs := observeLogs(t)
+	var seen handlerSeen
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", strings.Repeat("x", 200))
+	Middleware()(newTestHandler(&seen)).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Len(t, seen.reqID, 36, "the invalid ID should be replaced with a UUID")
+	entries := logs.FilterMessage("ctx logger").All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, strings.Repeat("x", 200), entries[0].ContextMap()[interceptor.ClientReqLogKey])
+	}
+
+	validation := WithRequestIDValidation(interceptor.RequestIDValidation{Pattern: regexp.MustCompile(`^req-\d+$`)})
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Custom-Id", "req-1")
+	Handler(newTestHandler(&seen), validation, WithRequestIDHeader("x-custom-id")).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "req-1", seen.reqID)
+	before := interceptor.InvalidRequestIDStats()
+	req.Header.Set("X-Custom-Id", "req-abc")
+	Handler(newTestHandler(&seen), validation, WithRequestIDHeader("x-custom-id")).ServeHTTP(httptest.NewRecorder(), req)
+	assert.NotEqual(t, "req-abc", seen.reqID)
+	assert.Equal(t, before.Replaced+1, interceptor.InvalidRequestIDStats().Replaced)
+}
+
+func TestMiddlewareRejectsInvalidRequestID(t *testing.T) {
+	observeLogs(t)
+	seen := handlerSeen{}
+	before := interceptor.InvalidRequestIDStats()
+	validation := WithRequestIDValidation(interceptor.RequestIDValidation{Format: interceptor.RequestIDFormatUUID, Reject: true})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-Id", "not-a-uuid")
+	rec := httptest.NewRecorder()
+	Middleware(validation)(newTestHandler(&seen)).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "not a UUID")
+	assert.Empty(t, seen.reqID, "the handler should not be called")
+	assert.Equal(t, before.Rejected+1, interceptor.InvalidRequestIDStats().Rejected)
+
+	rec = httptest.NewRecorder()
+	Middleware(validation)(newTestHandler(&seen)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code, "a missing ID should still be generated")
+}
+
+func TestMiddlewareTrace(t *testing.T) {
+	const traceID, spanID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	logs := observeLogs(t)
+	var seenTrace oteltrace.SpanContext
+	handler := http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		seenTrace = interceptor.TraceFromContext(r.Context())
+		logger.Ctx(r.Context()).Info("ctx logger")
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Traceparent", "00-"+traceID+"-"+spanID+"-01")
+	Middleware()(handler).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, traceID, seenTrace.TraceID().String(), "the trace should be available via TraceFromContext")
+	entries := logs.FilterMessage("ctx logger").All()
+	if assert.Len(t, entries, 1) {
+		fields := entries[0].ContextMap()
+		assert.Equal(t, traceID, fields[interceptor.TraceLogKey])
+		assert.Equal(t, spanID, fields[interceptor.SpanLogKey])
+		assert.Equal(t, true, fields[interceptor.TraceSampledLogKey])
+	}
+
+	logs.TakeAll()
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("B3", traceID+"-"+spanID+"-0")
+	Middleware()(handler).ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, spanID, seenTrace.SpanID().String())
+	entries = logs.FilterMessage("ctx logger").All()
+	if assert.Len(t, entries, 1) {
+		assert.NotContains(t, entries[0].ContextMap(), interceptor.TraceLogKey, "unsampled traces should not be logged by default")
+	}
+	logs.TakeAll()
+	Middleware(WithUnsampledTraces())(handler).ServeHTTP(httptest.NewRecorder(), req)
+	entries = logs.FilterMessage("ctx logger").All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, false, entries[0].ContextMap()[interceptor.TraceSampledLogKey])
+	}
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Config is the full logging configuration: a standard zap config, plus the extra settings supported by this package.
+// It is also the format of the JSON logging config file.
+type Config struct {
+	zap.Config
+	Routes   []LevelRoute `json:"routes,omitempty" yaml:"routes,omitempty"`     // Per level range outputs (replace outputPaths)
+	Schema   string       `json:"schema,omitempty" yaml:"schema,omitempty"`     // Cloud provider log schema: gcp, aws or ecs
+	Limits   *Limits      `json:"limits,omitempty" yaml:"limits,omitempty"`     // Message/field/entry size limits
+	Identity *Identity    `json:"identity,omitempty" yaml:"identity,omitempty"` // Automatic service identity fields
+}
+
+// DevConfig returns the Development logging config at the specified level.
+// Colored & aligned output is used when writing to a terminal (see NO_COLOR/FORCE_COLOR).
+func DevConfig(lvl zapcore.Level, outputs ...string) Config {
+	pc := zap.NewDevelopmentConfig()
+	pc.Level = zap.NewAtomicLevelAt(lvl)
+	if len(outputs) > 0 {
+		pc.OutputPaths = outputs
+	}
+	applyConsoleStyle(&pc) // Only use colors/alignment if writing to a terminal
+	return Config{Config: pc}
+}
+
+// ProdConfig returns the Production logging config at the specified level.
+func ProdConfig(lvl zapcore.Level, outputs ...string) Config {
+	pc := zap.NewProductionConfig()
+	pc.Level = zap.NewAtomicLevelAt(lvl)
+	if len(outputs) > 0 {
+		pc.OutputPaths = outputs
+	}
+	return Config{Config: pc}
+}
+
+// NewLoggerFromConfig creates a logger from the supplied config.
+func NewLoggerFromConfig(cfg Config) error {
+	if err := installLogger(cfg); err != nil {
+		return fmt.Errorf("failed to load logger: %v", err)
+	}
+	return nil
+}
+
+// NewSugaredLoggerFromConfig creates a sugared logger from the supplied config.
+func NewSugaredLoggerFromConfig(cfg Config) error {
+	return NewLoggerFromConfig(cfg)
+}
+
+// installLogger builds a logger from the config and makes it (and its atomic level) the global one.
+func installLogger(cfg Config) error {
+	l, err := buildLogger(cfg)
+	if err != nil {
+		return err
+	}
+	swapLogger(l, cfg.Level) // Level changes now need to target the new logger
+	return nil
+}
+
+// buildLogger creates a logger from the supplied config, adding support for schemas, identity fields, level routes,
+// size limits, runtime sinks and alert hooks.
+func buildLogger(cfg Config) (*zap.Logger, error) {
+	if err := applySchema(&cfg); err != nil {
+		return nil, err
+	}
+	if err := applyIdentity(&cfg); err != nil {
+		return nil, err
+	}
+	// Add the initial fields on top of the wrapped core, so that they also reach runtime sinks and alert hooks
+	fields := make([]zap.Field, 0, len(cfg.InitialFields))
+	for _, k := range slices.Sorted(maps.Keys(cfg.InitialFields)) {
+		fields = append(fields, zap.Any(k, cfg.InitialFields[k]))
+	}
+	cfg.InitialFields = nil
+	var limits Limits
+	if cfg.Limits != nil {
+		limits = *cfg.Limits
+	}
+	// Build the main core(s) with a permissive level, gating them with the atomic level instead,
+	// so that child loggers can override the level (see OverrideLevel)
+	level := cfg.Level
+	if level == (zap.AtomicLevel{}) { // i.e. no level in the config file (zap.Config.Build would reject it too)
+		return nil, errors.New("missing Level")
+	}
+	cfg.Level = zap.NewAtomicLevelAt(zapcore.DebugLevel)
+	wrap := func(core zapcore.Core) zapcore.Core { // Limit outside the sinks, so they (and alert hooks) also get truncated entries
+		return newLimitCore(newSinkCore(newLevelCore(core, level)), limits)
+	}
+	if len(cfg.Routes) == 0 {
+		return cfg.Build(zap.WrapCore(wrap), zap.Fields(fields...))
+	}
+	core, err := buildRouteCore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	base := cfg.Config
+	base.OutputPaths = nil // The route cores replace the default outputs
+	return base.Build(zap.WrapCore(func(zapcore.Core) zapcore.Core {
+		return wrap(core)
+	}), zap.Fields(fields...))
+}
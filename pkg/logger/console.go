@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	PrettyConsoleEncoding = "pretty-console" // Encoder name for the colored/aligned TTY console output
+	NoColorEnv            = "NO_COLOR"       // Disables colored output when set (https://no-color.org)
+	ForceColorEnv         = "FORCE_COLOR"    // Forces colored output when set (unless set to 0/false)
+
+	levelColumnWidth  = 5  // Width of the longest standard level name (DEBUG, ERROR, PANIC, FATAL)
+	callerColumnWidth = 28 // Padding applied to the caller column to keep messages aligned
+)
+
+// ANSI colour codes used to highlight each logging level.
+var levelColors = map[zapcore.Level]string{
+	zapcore.DebugLevel:  "\x1b[35m", // Magenta
+	zapcore.InfoLevel:   "\x1b[34m", // Blue
+	zapcore.WarnLevel:   "\x1b[33m", // Yellow
+	zapcore.ErrorLevel:  "\x1b[31m", // Red
+	zapcore.DPanicLevel: "\x1b[31m",
+	zapcore.PanicLevel:  "\x1b[31m",
+	zapcore.FatalLevel:  "\x1b[31m",
+}
+
+const colorReset = "\x1b[0m"
+
+func init() {
+	if err := zap.RegisterEncoder(PrettyConsoleEncoding, newPrettyConsoleEncoder); err != nil {
+		panic(fmt.Sprintf("failed to register %v encoder: %v", PrettyConsoleEncoding, err))
+	}
+}
+
+// colorEnabled decides if colored console output should be used for the given outputs.
+// NO_COLOR always wins, FORCE_COLOR enables it regardless of the outputs, otherwise every output needs to be a terminal.
+func colorEnabled(outputs []string) bool {
+	if len(os.Getenv(NoColorEnv)) > 0 {
+		return false
+	}
+	if force, ok := os.LookupEnv(ForceColorEnv); ok {
+		switch strings.ToLower(strings.TrimSpace(force)) {
+		case "0", "false", "no", "off":
+			return false
+		default:
+			return true
+		}
+	}
+	if len(outputs) == 0 {
+		return false
+	}
+	for _, output := range outputs {
+		switch output {
+		case "stdout":
+			if !isTerminal(os.Stdout) {
+				return false
+			}
+		case "stderr":
+			if !isTerminal(os.Stderr) {
+				return false
+			}
+		default:
+			return false // files, URLs, etc. never get colors
+		}
+	}
+	return true
+}
+
+// isTerminal reports whether the given file is attached to a character device (i.e. a TTY).
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// applyConsoleStyle switches a development config over to the pretty console encoder if the outputs are terminals.
+func applyConsoleStyle(pc *zap.Config) {
+	if pc.Encoding != "console" || !colorEnabled(pc.OutputPaths) {
+		return
+	}
+	pc.Encoding = PrettyConsoleEncoding
+	pc.EncoderConfig.EncodeLevel = alignedColorLevelEncoder
+	pc.EncoderConfig.EncodeCaller = alignedCallerEncoder
+}
+
+// alignedColorLevelEncoder serialises a Level to a coloured, fixed width, all-caps string.
+func alignedColorLevelEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	name := fmt.Sprintf("%-*s", levelColumnWidth, l.CapitalString())
+	if color, ok := levelColors[l]; ok {
+		enc.AppendString(color + name + colorReset)
+	} else {
+		enc.AppendString(name)
+	}
+}
+
+// alignedCallerEncoder serialises the short caller, padded to a fixed width.
+func alignedCallerEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendString(fmt.Sprintf("%-*s", callerColumnWidth, caller.TrimmedPath()))
+}
+
+// prettyConsoleEncoder writes the entry line using the console encoder,
+// followed by any structured fields as indented JSON on the following lines.
+type prettyConsoleEncoder struct {
+	zapcore.Encoder // JSON encoder holding the accumulated context fields
+	console         zapcore.Encoder
+}
+
+// newPrettyConsoleEncoder creates a new pretty console encoder from the given config.
+func newPrettyConsoleEncoder(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+	fieldsCfg := zapcore.EncoderConfig{
+		SkipLineEnding: true,
+		EncodeDuration: cfg.EncodeDuration,
+		EncodeTime:     cfg.EncodeTime,
+	}
+	if fieldsCfg.EncodeDuration == nil {
+		fieldsCfg.EncodeDuration = zapcore.StringDurationEncoder
+	}
+	if fieldsCfg.EncodeTime == nil {
+		fieldsCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+	return prettyConsoleEncoder{
+		Encoder: zapcore.NewJSONEncoder(fieldsCfg),
+		console: zapcore.NewConsoleEncoder(cfg),
+	}, nil
+}
+
+// Clone copies the encoder, including any accumulated fields.
+func (e prettyConsoleEncoder) Clone() zapcore.Encoder {
+	return prettyConsoleEncoder{
+		Encoder: e.Encoder.Clone(),
+		console: e.console,
+	}
+}
+
+// EncodeEntry encodes the entry on a single aligned line and pretty-prints the fields underneath it.
+func (e prettyConsoleEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	stack := ent.Stack
+	ent.Stack = ""
+	line, err := e.console.EncodeEntry(ent, nil)
+	if err != nil {
+		return nil, err
+	}
+	fieldBuf, err := e.Encoder.EncodeEntry(zapcore.Entry{}, fields)
+	if err != nil {
+		line.Free()
+		return nil, err
+	}
+	defer fieldBuf.Free()
+	if raw := fieldBuf.Bytes(); len(raw) > 2 { // Skip empty '{}' field sets
+		var pretty bytes.Buffer
+		if json.Indent(&pretty, raw, "\t", "  ") != nil {
+			pretty.Reset()
+			_, _ = pretty.Write(raw) // Fall back to the compact form
+		}
+		line.AppendByte('\t')
+		_, _ = line.Write(pretty.Bytes())
+		line.AppendByte('\n')
+	}
+	if len(stack) > 0 {
+		line.AppendString(stack)
+		line.AppendByte('\n')
+	}
+	return line, nil
+}
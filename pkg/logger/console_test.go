@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestColorEnabled(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	t.Setenv(ForceColorEnv, "1")
+	if !colorEnabled([]string{"test.log"}) {
+		t.Errorf("expected FORCE_COLOR to enable colors")
+	}
+	t.Setenv(ForceColorEnv, "0")
+	if colorEnabled([]string{"stdout"}) {
+		t.Errorf("expected FORCE_COLOR=0 to disable colors")
+	}
+	t.Setenv(NoColorEnv, "1")
+	t.Setenv(ForceColorEnv, "1")
+	if colorEnabled([]string{"stdout"}) {
+		t.Errorf("expected NO_COLOR to take precedence over FORCE_COLOR")
+	}
+}
+
+func TestColorEnabledFile(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	if err := unsetEnv(t, ForceColorEnv); err != nil {
+		t.Fatalf("failed to unset %v: %v", ForceColorEnv, err)
+	}
+	if colorEnabled([]string{"stdout", "test.log"}) {
+		t.Errorf("expected no colors when writing to a file")
+	}
+	if colorEnabled(nil) {
+		t.Errorf("expected no colors without outputs")
+	}
+}
+
+func TestApplyConsoleStyle(t *testing.T) {
+	t.Setenv(NoColorEnv, "")
+	t.Setenv(ForceColorEnv, "true")
+	pc := zap.NewDevelopmentConfig()
+	applyConsoleStyle(&pc)
+	if pc.Encoding != PrettyConsoleEncoding {
+		t.Errorf("expected encoding %v, got %v", PrettyConsoleEncoding, pc.Encoding)
+	}
+	t.Setenv(NoColorEnv, "1")
+	pc = zap.NewDevelopmentConfig()
+	applyConsoleStyle(&pc)
+	if pc.Encoding != "console" {
+		t.Errorf("expected plain console encoding, got %v", pc.Encoding)
+	}
+	pc = zap.NewProductionConfig()
+	applyConsoleStyle(&pc)
+	if pc.Encoding != "json" {
+		t.Errorf("expected json encoding to be untouched, got %v", pc.Encoding)
+	}
+}
+
+func TestPrettyConsoleEncoder(t *testing.T) {
+	cfg := zap.NewDevelopmentEncoderConfig()
+	cfg.EncodeLevel = alignedColorLevelEncoder
+	enc, err := newPrettyConsoleEncoder(cfg)
+	if err != nil {
+		t.Fatalf("unexpected error creating encoder: %v", err)
+	}
+	enc.AddString("service", "test")
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Time: time.Now(), Message: "pretty message"}
+	buf, err := enc.Clone().EncodeEntry(ent, []zapcore.Field{zap.Int("count", 2)})
+	if err != nil {
+		t.Fatalf("unexpected error encoding entry: %v", err)
+	}
+	out := buf.String()
+	t.Logf("Output:\n%v", out)
+	if !strings.Contains(out, levelColors[zapcore.InfoLevel]+"INFO ") {
+		t.Errorf("expected coloured, aligned level in output: %q", out)
+	}
+	if !strings.Contains(out, "pretty message\n\t{\n\t  \"service\": \"test\",\n\t  \"count\": 2\n\t}\n") {
+		t.Errorf("expected pretty printed fields in output: %q", out)
+	}
+	buf, err = enc.EncodeEntry(ent, nil)
+	if err != nil {
+		t.Fatalf("unexpected error encoding entry: %v", err)
+	}
+	if strings.Contains(buf.String(), "count") {
+		t.Errorf("did not expect entry fields to be accumulated: %q", buf.String())
+	}
+}
+
+// unsetEnv removes the given environment variable, restoring it at the end of the test.
+func unsetEnv(t *testing.T, key string) error {
+	t.Helper()
+	t.Setenv(key, "") // registers the cleanup to restore the original value
+	return os.Unsetenv(key)
+}
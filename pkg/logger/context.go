@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Standard logging field keys for request correlation.
+const (
+	RequestIDLogKey = "reqId"
+	TraceIDLogKey   = "trace_id"
+	SpanIDLogKey    = "span_id"
+)
+
+type ctxFieldsKey struct{} // Used for storing logging fields in a context
+
+// WithFields returns a copy of the context carrying the given logging fields (in addition to any already present).
+// These fields are added to every log message written via Ctx/SCtx using this context (or one derived from it).
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	existing := contextFields(ctx)
+	merged := make([]zap.Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, ctxFieldsKey{}, merged)
+}
+
+// Fields returns the logging fields carried by the context. These include the gRPC middleware tags (grpc_ctxtags),
+// the fields added by WithFields (including those from the gRPC interceptors in this module)
+// and the trace/span IDs of any sampled OpenTelemetry span.
+// If a key is set more than once, the latest value wins.
+func Fields(ctx context.Context) []zap.Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []zap.Field
+	index := make(map[string]int)
+	add := func(f zap.Field) {
+		if i, ok := index[f.Key]; ok {
+			fields[i] = f
+			return
+		}
+		index[f.Key] = len(fields)
+		fields = append(fields, f)
+	}
+	for _, f := range ctxzap.TagsToFields(ctx) {
+		add(f)
+	}
+	for _, f := range contextFields(ctx) {
+		add(f)
+	}
+	if span := oteltrace.SpanContextFromContext(ctx); span.IsSampled() {
+		if _, ok := index[TraceIDLogKey]; !ok {
+			add(zap.String(TraceIDLogKey, span.TraceID().String()))
+		}
+		if _, ok := index[SpanIDLogKey]; !ok {
+			add(zap.String(SpanIDLogKey, span.SpanID().String()))
+		}
+	}
+	return fields
+}
+
+// Ctx returns the global logger, enriched with the logging fields (and any level override) carried by the context.
+func Ctx(ctx context.Context) *zap.Logger {
+	l := Get()
+	if lvl, ok := LevelOverride(ctx); ok {
+		l = OverrideLevel(l, lvl)
+	}
+	if fields := Fields(ctx); len(fields) > 0 {
+		return l.With(fields...)
+	}
+	return l
+}
+
+// SCtx returns the global sugared logger, enriched with the logging fields carried by the context.
+func SCtx(ctx context.Context) *zap.SugaredLogger {
+	return Ctx(ctx).Sugar()
+}
+
+// contextFields returns the fields added to the context using WithFields.
+func contextFields(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(ctxFieldsKey{}).([]zap.Field)
+	return fields
+}
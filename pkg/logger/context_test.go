@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"testing"
+
+	grpc_ctxtags "github.com/grpc-ecosystem/go-grpc-middleware/tags"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestContextFields(t *testing.T) {
+	ctx := context.Background()
+	if fields := Fields(ctx); len(fields) != 0 {
+		t.Errorf("expected no fields from an empty context, got: %v", fields)
+	}
+	ctx = WithFields(ctx, zap.String(RequestIDLogKey, "1234"), zap.String("tenant", "a"))
+	child := WithFields(ctx, zap.String("tenant", "b"), zap.Int("attempt", 2))
+	fields := Fields(child)
+	if len(fields) != 3 {
+		t.Fatalf("expected 3 fields, got %d: %v", len(fields), fields)
+	}
+	if fields[1].Key != "tenant" || fields[1].String != "b" {
+		t.Errorf("expected the latest tenant value to win, got: %v", fields[1])
+	}
+	if parent := Fields(ctx); len(parent) != 2 || parent[1].String != "a" {
+		t.Errorf("expected the parent context to be unchanged, got: %v", parent)
+	}
+	if WithFields(ctx) != ctx {
+		t.Errorf("expected the same context when no fields are supplied")
+	}
+}
+
+func TestContextFieldsTagsAndTrace(t *testing.T) {
+	ctx := grpc_ctxtags.SetInContext(context.Background(), grpc_ctxtags.NewTags())
+	grpc_ctxtags.Extract(ctx).Set(RequestIDLogKey, "from-tags").Set("grpc.method", "Echo")
+	ctx = WithFields(ctx, zap.String(RequestIDLogKey, "from-logger"))
+	traceID, _ := oteltrace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	spanID, _ := oteltrace.SpanIDFromHex("b7ad6b7169203331")
+	ctx = oteltrace.ContextWithSpanContext(ctx, oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID: traceID, SpanID: spanID, TraceFlags: oteltrace.FlagsSampled,
+	}))
+	got := make(map[string]string)
+	for _, f := range Fields(ctx) {
+		got[f.Key] = f.String
+	}
+	expected := map[string]string{
+		RequestIDLogKey: "from-logger",
+		"grpc.method":   "Echo",
+		TraceIDLogKey:   traceID.String(),
+		SpanIDLogKey:    spanID.String(),
+	}
+	for k, v := range expected {
+		if got[k] != v {
+			t.Errorf("expected field %v=%v, got: %v", k, v, got[k])
+		}
+	}
+}
+
+func TestCtxLogger(t *testing.T) {
+	Set(nil)
+	Ctx(context.Background()).Info("nop logger message") // Should not panic without a logger
+	core, logs := observer.New(zap.DebugLevel)
+	Set(zap.New(core))
+	ctx := WithFields(context.Background(), zap.String(RequestIDLogKey, "abcd"))
+	Ctx(ctx).Info("ctx message")
+	SCtx(ctx).Infof("sugared %v message", "ctx")
+	if logs.Len() != 2 {
+		t.Fatalf("expected 2 log messages, got %d", logs.Len())
+	}
+	for _, entry := range logs.All() {
+		if entry.ContextMap()[RequestIDLogKey] != "abcd" {
+			t.Errorf("expected request id in log message '%v', got: %v", entry.Message, entry.ContextMap())
+		}
+	}
+}
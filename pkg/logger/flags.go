@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Standard logging flag names.
+const (
+	FlagLogMode            = "log-mode"
+	FlagLogLevel           = "log-level"
+	FlagLogConfig          = "log-config"
+	FlagLogOutputs         = "log-outputs"
+	FlagLogStrict          = "log-strict"
+	FlagLogCrashOutput     = "log-crash-output"
+	FlagLogSchema          = "log-schema"
+	FlagDebug              = "debug"
+	FlagDynamicLogging     = "dynamic-logging"
+	FlagDynamicLoggingPort = "dynamic-logging-port"
+)
+
+// FlagSet is the subset of flag registration methods shared by *flag.FlagSet and *pflag.FlagSet
+// (and therefore cobra's cmd.Flags()/cmd.PersistentFlags()).
+type FlagSet interface {
+	StringVar(p *string, name string, value string, usage string)
+	BoolVar(p *bool, name string, value bool, usage string)
+}
+
+// AppConfig holds the standard application logging settings, which can be bound to command line flags.
+type AppConfig struct {
+	Mode               string // Logging mode: dev or prod
+	Level              string // Initial logging level (overrides the mode/config default)
+	ConfigFile         string // JSON zap config file(s), comma separated overlays are merged in order
+	Outputs            string // Comma separated list of outputs (stdout/stderr/file)
+	Strict             bool   // Validate the config file before loading it
+	CrashOutput        string // File to write fatal runtime errors to
+	Schema             string // Cloud provider log schema (gcp, aws or ecs)
+	Debug              bool   // Enable debug logging
+	DynamicLogging     bool   // Enable the dynamic logging HTTP interface
+	DynamicLoggingPort string // Address for the dynamic logging HTTP interface
+}
+
+// RegisterFlags registers the standard logging flags on the given flag set, binding them to this config.
+// The current config values are used as the flag defaults.
+func (c *AppConfig) RegisterFlags(fs FlagSet) {
+	if len(c.Mode) == 0 {
+		c.Mode = "dev"
+	}
+	if len(c.DynamicLoggingPort) == 0 {
+		c.DynamicLoggingPort = "localhost:1065"
+	}
+	fs.StringVar(&c.Mode, FlagLogMode, c.Mode, "Logging mode (dev or prod)")
+	fs.StringVar(&c.Level, FlagLogLevel, c.Level, "Logging level (debug, info, warn, error, dpanic, panic, fatal)")
+	fs.StringVar(&c.ConfigFile, FlagLogConfig, c.ConfigFile, "JSON zap logging config file(s) (comma separated files are merged in order)")
+	fs.StringVar(&c.Outputs, FlagLogOutputs, c.Outputs, "Comma separated list of log outputs (stdout, stderr or file path)")
+	fs.BoolVar(&c.Strict, FlagLogStrict, c.Strict, "Validate the logging config file before loading it")
+	fs.StringVar(&c.CrashOutput, FlagLogCrashOutput, c.CrashOutput, "File to write fatal runtime errors (crashes) to")
+	fs.StringVar(&c.Schema, FlagLogSchema, c.Schema, "Cloud provider log schema (gcp, aws or ecs)")
+	fs.BoolVar(&c.Debug, FlagDebug, c.Debug, "Enable debug logging")
+	fs.BoolVar(&c.DynamicLogging, FlagDynamicLogging, c.DynamicLogging, "Enable the dynamic logging level HTTP interface")
+	fs.StringVar(&c.DynamicLoggingPort, FlagDynamicLoggingPort, c.DynamicLoggingPort, "Address for the dynamic logging level HTTP interface")
+}
+
+// OutputList returns the configured outputs as a list.
+func (c *AppConfig) OutputList() []string {
+	var outputs []string
+	for _, output := range strings.Split(c.Outputs, ",") {
+		if output = strings.TrimSpace(output); len(output) > 0 {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs
+}
+
+// ConfigFileList returns the configured config files as a list (the main file, followed by any overlays).
+func (c *AppConfig) ConfigFileList() []string {
+	var files []string
+	for _, file := range strings.Split(c.ConfigFile, ",") {
+		if file = strings.TrimSpace(file); len(file) > 0 {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Apply creates the global loggers from the config and starts the dynamic logging interface (if requested).
+func (c *AppConfig) Apply() error {
+	if len(c.Level) > 0 {
+		if _, err := zapcore.ParseLevel(c.Level); err != nil {
+			return fmt.Errorf("invalid logging level '%v': %v", c.Level, err)
+		}
+	}
+	opts := []Option{WithOutputs(c.OutputList()...)}
+	if c.Strict {
+		opts = append(opts, WithStrictConfig())
+	}
+	if len(c.CrashOutput) > 0 {
+		opts = append(opts, WithCrashOutput(c.CrashOutput))
+	}
+	if len(c.Schema) > 0 {
+		opts = append(opts, WithSchema(c.Schema))
+	}
+	var configFile string
+	if files := c.ConfigFileList(); len(files) > 0 {
+		configFile = files[0]
+		opts = append(opts, WithConfigOverlays(files[1:]...))
+	}
+	if err := SetupAppLoggerWithOptions(c.Mode, configFile, c.Debug, opts...); err != nil {
+		return err
+	}
+	if len(c.Level) > 0 && !c.Debug { // Debug takes precedence over the requested level
+		SetLevel(c.Level)
+	}
+	SetupAppDynamicLogging(c.DynamicLoggingPort, c.DynamicLogging)
+	return nil
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAppConfigFlags(t *testing.T) {
+	var cfg AppConfig
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg.RegisterFlags(fs)
+	err := fs.Parse([]string{"--log-mode", "prod", "--log-level", "warn", "--log-outputs", "stdout, ,stderr", "--log-strict"})
+	if err != nil {
+		t.Fatalf("unexpected error parsing flags: %v", err)
+	}
+	if cfg.Mode != "prod" || cfg.Level != "warn" || !cfg.Strict || cfg.Debug {
+		t.Errorf("unexpected config values: %+v", cfg)
+	}
+	if outputs := cfg.OutputList(); len(outputs) != 2 || outputs[0] != "stdout" || outputs[1] != "stderr" {
+		t.Errorf("unexpected outputs: %v", outputs)
+	}
+	if cfg.DynamicLoggingPort != "localhost:1065" {
+		t.Errorf("unexpected default dynamic logging port: %v", cfg.DynamicLoggingPort)
+	}
+	if err = cfg.Apply(); err != nil {
+		t.Fatalf("unexpected error applying config: %v", err)
+	}
+	defer SyncZap()
+	if currentLevel().Level() != zapcore.WarnLevel {
+		t.Errorf("expected level warn, got %v", currentLevel().Level())
+	}
+}
+
+func TestAppConfigApply(t *testing.T) {
+	cfg := AppConfig{Mode: "dev", Level: "verbose"}
+	if err := cfg.Apply(); err == nil {
+		t.Errorf("expected an error from an invalid level")
+	}
+	cfg = AppConfig{Mode: "prod", ConfigFile: "./tests/zap_config-invalid.json", Strict: true}
+	if err := cfg.Apply(); err == nil {
+		t.Errorf("expected an error from an invalid config file")
+	}
+	cfg = AppConfig{Mode: "dev", Level: "error", Debug: true, DynamicLogging: true, DynamicLoggingPort: ":0"}
+	if err := cfg.Apply(); err != nil {
+		t.Fatalf("unexpected error applying config: %v", err)
+	}
+	if currentLevel().Level() != zapcore.DebugLevel {
+		t.Errorf("expected debug to take precedence, got %v", currentLevel().Level())
+	}
+}
+
+func TestAppConfigDevOutputs(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "dev.log")
+	cfg := AppConfig{Mode: "dev", Outputs: logFile}
+	if err := cfg.Apply(); err != nil {
+		t.Fatalf("unexpected error applying config: %v", err)
+	}
+	Get().Info("Dev output message")
+	SyncZap()
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "Dev output message") {
+		t.Errorf("expected the dev mode logs in %v, got: %v", logFile, string(data))
+	}
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelChangeSource identifies what triggered a logging level change.
+type LevelChangeSource string
+
+const (
+	LevelSourceCode   LevelChangeSource = "code"   // SetLevel called from the application
+	LevelSourceHTTP   LevelChangeSource = "http"   // PUT request to the dynamic logging interface
+	LevelSourceSignal LevelChangeSource = "signal" // OS signal handler
+	LevelSourceFile   LevelChangeSource = "file"   // Logging config file (re)load
+
+	DefaultLevelHistorySize = 100 // Default number of level changes to remember
+)
+
+// LevelChange records a single change of the logging level.
+type LevelChange struct {
+	Time       time.Time         `json:"time"`
+	OldLevel   string            `json:"old_level"`
+	NewLevel   string            `json:"new_level"`
+	Source     LevelChangeSource `json:"source"`
+	RemoteAddr string            `json:"remote_addr,omitempty"` // Only set for HTTP changes
+}
+
+// levelChangeHistory is a bounded, thread safe, list of level changes (oldest first).
+type levelChangeHistory struct {
+	mu      sync.Mutex
+	size    int
+	changes []LevelChange
+}
+
+var levelHistory = &levelChangeHistory{size: DefaultLevelHistorySize} // Level change audit history
+
+// add records a new level change, dropping the oldest entry if the history is full.
+func (h *levelChangeHistory) add(change LevelChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.size <= 0 {
+		return
+	}
+	h.changes = append(h.changes, change)
+	if over := len(h.changes) - h.size; over > 0 {
+		h.changes = append(h.changes[:0:0], h.changes[over:]...)
+	}
+}
+
+// list returns a copy of the recorded level changes.
+func (h *levelChangeHistory) list() []LevelChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]LevelChange{}, h.changes...)
+}
+
+// resize changes the maximum number of entries kept, dropping the oldest ones if necessary.
+func (h *levelChangeHistory) resize(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.size = size
+	if size <= 0 {
+		h.changes = nil
+	} else if over := len(h.changes) - size; over > 0 {
+		h.changes = append(h.changes[:0:0], h.changes[over:]...)
+	}
+}
+
+// LevelHistory returns the recorded logging level changes (oldest first).
+func LevelHistory() []LevelChange {
+	return levelHistory.list()
+}
+
+// SetLevelHistorySize sets the maximum number of level changes to remember. Zero disables the history.
+func SetLevelHistorySize(size int) {
+	levelHistory.resize(size)
+}
+
+// recordLevelChange adds a level change to the history, if the level actually changed.
+func recordLevelChange(oldLevel, newLevel zapcore.Level, source LevelChangeSource, remoteAddr string) {
+	if oldLevel == newLevel {
+		return
+	}
+	levelHistory.add(LevelChange{
+		Time:       time.Now().UTC(),
+		OldLevel:   oldLevel.String(),
+		NewLevel:   newLevel.String(),
+		Source:     source,
+		RemoteAddr: remoteAddr,
+	})
+}
+
+// levelHandler serves the current atomic level over HTTP, recording any changes made via PUT requests.
+func levelHandler(w http.ResponseWriter, r *http.Request) {
+	lvl := currentLevel()
+	oldLevel := lvl.Level()
+	lvl.ServeHTTP(w, r)
+	if r.Method == http.MethodPut {
+		if newLevel := lvl.Level(); newLevel != oldLevel {
+			recordLevelChange(oldLevel, newLevel, LevelSourceHTTP, r.RemoteAddr)
+			logMsg(zapcore.InfoLevel, fmt.Sprintf("Logging level changed from %v to %v by %v.", oldLevel, newLevel, r.RemoteAddr))
+		}
+	}
+}
+
+// levelHistoryHandler serves the level change history as JSON.
+func levelHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, fmt.Sprintf("Only %v is supported", http.MethodGet), http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(LevelHistory()); err != nil {
+		logMsg(zapcore.WarnLevel, fmt.Sprintf("Failed to write level history: %v", err))
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelHistory(t *testing.T) {
+	SetLevelHistorySize(DefaultLevelHistorySize)
+	defer SetLevelHistorySize(DefaultLevelHistorySize)
+	if err := NewProdLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	SetLevelHistorySize(0) // Clear the history
+	SetLevelHistorySize(3)
+	SetLevel("debug")
+	SetLevel("debug") // No change, so not recorded
+	SetLevelFrom("warn", LevelSourceSignal)
+	SetLevel("random")
+	history := LevelHistory()
+	if len(history) != 2 {
+		t.Fatalf("expected 2 level changes, got %d: %+v", len(history), history)
+	}
+	if history[0].OldLevel != "info" || history[0].NewLevel != "debug" || history[0].Source != LevelSourceCode {
+		t.Errorf("unexpected first level change: %+v", history[0])
+	}
+	if history[1].NewLevel != "warn" || history[1].Source != LevelSourceSignal {
+		t.Errorf("unexpected second level change: %+v", history[1])
+	}
+	SetLevel("error")
+	SetLevel("info")
+	history = LevelHistory()
+	if len(history) != 3 || history[0].NewLevel != "warn" || history[2].NewLevel != "info" {
+		t.Errorf("expected the oldest change to be dropped: %+v", history)
+	}
+	SetLevelHistorySize(1)
+	if history = LevelHistory(); len(history) != 1 || history[0].NewLevel != "info" {
+		t.Errorf("expected only the latest change after resize: %+v", history)
+	}
+}
+
+func TestLevelHistoryHTTP(t *testing.T) {
+	defer SetLevelHistorySize(DefaultLevelHistorySize)
+	if err := NewProdLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	SetLevelHistorySize(0)
+	SetLevelHistorySize(DefaultLevelHistorySize)
+	req := httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"debug"}`))
+	req.RemoteAddr = "10.0.0.1:1234"
+	rec := httptest.NewRecorder()
+	levelHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("unexpected status code setting level: %v - %v", rec.Code, rec.Body.String())
+	}
+	if currentLevel().Level() != zapcore.DebugLevel {
+		t.Errorf("expected level to be debug, got %v", currentLevel().Level())
+	}
+	rec = httptest.NewRecorder()
+	levelHistoryHandler(rec, httptest.NewRequest(http.MethodGet, "/log/level/history", nil))
+	var history []LevelChange
+	if err := json.Unmarshal(rec.Body.Bytes(), &history); err != nil {
+		t.Fatalf("failed to parse level history response '%v': %v", rec.Body.String(), err)
+	}
+	if len(history) != 1 || history[0].Source != LevelSourceHTTP || history[0].RemoteAddr != "10.0.0.1:1234" {
+		t.Errorf("unexpected level history: %+v", history)
+	}
+	rec = httptest.NewRecorder()
+	levelHistoryHandler(rec, httptest.NewRequest(http.MethodPost, "/log/level/history", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected method not allowed, got: %v", rec.Code)
+	}
+}
+
+func TestLevelHistoryFileReload(t *testing.T) {
+	defer SetLevelHistorySize(DefaultLevelHistorySize)
+	if err := NewDevLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a dev logger", err)
+	}
+	SetLevelHistorySize(0)
+	SetLevelHistorySize(DefaultLevelHistorySize)
+	if err := NewLoggerFromFile("./tests/zap_config.json"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a json logger", err)
+	}
+	history := LevelHistory()
+	if len(history) != 1 || history[0].Source != LevelSourceFile || history[0].NewLevel != "info" {
+		t.Errorf("unexpected level history: %+v", history)
+	}
+}
@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultAlertQueueSize      = 100             // Default number of alerts buffered per hook
+	defaultWebhookTimeout      = 5 * time.Second // Default timeout for each webhook attempt
+	defaultWebhookRetryBackoff = time.Second     // Default delay before the first webhook retry (doubles after each attempt)
+)
+
+// AlertRecord is the payload sent to alert hooks for each high-severity log entry.
+type AlertRecord struct {
+	Time      time.Time      `json:"time"`
+	Level     string         `json:"level"`
+	Logger    string         `json:"logger,omitempty"`
+	Message   string         `json:"message"`
+	Caller    string         `json:"caller,omitempty"`
+	Stack     string         `json:"stack,omitempty"`
+	RequestID string         `json:"request_id,omitempty"`
+	TraceID   string         `json:"trace_id,omitempty"`
+	SpanID    string         `json:"span_id,omitempty"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+// AlertFunc is called (asynchronously) for each alert record.
+type AlertFunc func(AlertRecord)
+
+// AlertHook describes a callback to fire when records at or above a threshold level are logged.
+type AlertHook struct {
+	Name       string        // Unique name of the hook
+	MinLevel   zapcore.Level // Minimum level to alert on
+	Handler    AlertFunc     // Callback to run for each alert (i.e. WebhookAlertHandler)
+	RateLimit  int           // Maximum number of alerts per RateWindow (0 for unlimited)
+	RateWindow time.Duration // Rate limiting window (default: 1 minute)
+	QueueSize  int           // Number of alerts buffered before dropping (default: 100)
+}
+
+// alertHook is a registered hook, along with its queue and rate limiter.
+type alertHook struct {
+	cfg     AlertHook
+	queue   chan AlertRecord
+	stop    chan struct{} // Closed when the hook is removed
+	done    chan struct{} // Closed once the queued alerts have been delivered
+	limiter *rateLimiter
+}
+
+// alertRegistry holds the registered alert hooks. Readers get a lock free snapshot, writers serialise on the mutex.
+type alertRegistry struct {
+	mu    sync.Mutex
+	hooks atomic.Pointer[[]*alertHook]
+}
+
+var alertHooks = &alertRegistry{} // Registered alert hooks
+
+// snapshot returns the currently registered hooks.
+func (r *alertRegistry) snapshot() []*alertHook {
+	if hooks := r.hooks.Load(); hooks != nil {
+		return *hooks
+	}
+	return nil
+}
+
+// enabled reports whether any hook alerts at the given level.
+func (r *alertRegistry) enabled(lvl zapcore.Level) bool {
+	for _, h := range r.snapshot() {
+		if lvl >= h.cfg.MinLevel {
+			return true
+		}
+	}
+	return false
+}
+
+// AddAlertHook registers a hook to be called asynchronously when records at or above its level are logged.
+// Logging never blocks on a hook: alerts are dropped if the hook's queue is full or its rate limit is exceeded.
+func AddAlertHook(hook AlertHook) error {
+	if len(hook.Name) == 0 {
+		return errors.New("no alert hook name provided")
+	}
+	if hook.Handler == nil {
+		return fmt.Errorf("no handler provided for alert hook '%v'", hook.Name)
+	}
+	if hook.QueueSize <= 0 {
+		hook.QueueSize = defaultAlertQueueSize
+	}
+	if hook.RateWindow <= 0 {
+		hook.RateWindow = time.Minute
+	}
+	alertHooks.mu.Lock()
+	defer alertHooks.mu.Unlock()
+	current := alertHooks.snapshot()
+	for _, h := range current {
+		if h.cfg.Name == hook.Name {
+			return fmt.Errorf("alert hook '%v' already exists", hook.Name)
+		}
+	}
+	h := &alertHook{
+		cfg:     hook,
+		queue:   make(chan AlertRecord, hook.QueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		limiter: &rateLimiter{limit: hook.RateLimit, window: hook.RateWindow},
+	}
+	go h.run()
+	updated := append(slices.Clone(current), h)
+	alertHooks.hooks.Store(&updated)
+	return nil
+}
+
+// RemoveAlertHook unregisters the named hook, waiting for any queued alerts to be delivered.
+func RemoveAlertHook(name string) error {
+	alertHooks.mu.Lock()
+	current := alertHooks.snapshot()
+	i := slices.IndexFunc(current, func(h *alertHook) bool { return h.cfg.Name == name })
+	if i < 0 {
+		alertHooks.mu.Unlock()
+		return fmt.Errorf("alert hook '%v' does not exist", name)
+	}
+	h := current[i]
+	updated := slices.Delete(slices.Clone(current), i, i+1)
+	alertHooks.hooks.Store(&updated)
+	alertHooks.mu.Unlock()
+	close(h.stop) // The queue is left open, so that concurrent dispatches never send on a closed channel
+	<-h.done
+	return nil
+}
+
+// run delivers the queued alerts to the hook's handler, until the hook is removed.
+func (h *alertHook) run() {
+	defer close(h.done)
+	for {
+		select {
+		case record := <-h.queue:
+			h.deliver(record)
+		case <-h.stop:
+			for { // Deliver any alerts queued before the hook was removed
+				select {
+				case record := <-h.queue:
+					h.deliver(record)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver calls the hook's handler, recovering from any panic.
+func (h *alertHook) deliver(record AlertRecord) {
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: alert hook '%v' panicked: %v\n", h.cfg.Name, r)
+		}
+	}()
+	h.cfg.Handler(record)
+}
+
+// dispatch queues the record for the hook, without blocking.
+func (h *alertHook) dispatch(record AlertRecord) {
+	select {
+	case <-h.stop:
+		return // The hook has been removed in the meantime
+	default:
+	}
+	if !h.limiter.allow(time.Now()) {
+		return
+	}
+	select {
+	case h.queue <- record:
+	default:
+		_, _ = fmt.Fprintf(os.Stderr, "Warning: alert hook '%v' queue is full. Dropping alert: %v\n", h.cfg.Name, record.Message)
+	}
+}
+
+// rateLimiter allows a fixed number of events per window.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	start  time.Time
+	count  int
+}
+
+// allow reports whether another event is allowed in the current window.
+func (r *rateLimiter) allow(now time.Time) bool {
+	if r.limit <= 0 {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.start) >= r.window {
+		r.start, r.count = now, 0
+	}
+	if r.count >= r.limit {
+		return false
+	}
+	r.count++
+	return true
+}
+
+// alertCore is a zap core which converts entries into alert records and dispatches them to the hooks.
+type alertCore struct {
+	fields []zapcore.Field
+}
+
+// Enabled reports whether any hook alerts at the given level.
+func (c *alertCore) Enabled(lvl zapcore.Level) bool {
+	return alertHooks.enabled(lvl)
+}
+
+// With adds structured context to the alert records.
+func (c *alertCore) With(fields []zapcore.Field) zapcore.Core {
+	return &alertCore{fields: append(slices.Clip(c.fields), fields...)}
+}
+
+// Check adds the alert core to the checked entry if any hook alerts at the entry level.
+func (c *alertCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write converts the entry into an alert record and dispatches it to the matching hooks.
+func (c *alertCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var record *AlertRecord
+	for _, h := range alertHooks.snapshot() {
+		if ent.Level < h.cfg.MinLevel {
+			continue
+		}
+		if record == nil {
+			record = newAlertRecord(ent, c.fields, fields)
+		}
+		h.dispatch(*record)
+	}
+	return nil
+}
+
+// Sync is a no-op, as alerts are delivered asynchronously.
+func (c *alertCore) Sync() error {
+	return nil
+}
+
+// newAlertRecord creates the alert payload from a log entry and its fields.
+func newAlertRecord(ent zapcore.Entry, contextFields, fields []zapcore.Field) *AlertRecord {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range contextFields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	record := &AlertRecord{
+		Time:    ent.Time,
+		Level:   ent.Level.String(),
+		Logger:  ent.LoggerName,
+		Message: ent.Message,
+		Stack:   ent.Stack,
+	}
+	if ent.Caller.Defined {
+		record.Caller = ent.Caller.TrimmedPath()
+	}
+	record.RequestID = popString(enc.Fields, RequestIDLogKey)
+	record.TraceID = popString(enc.Fields, TraceIDLogKey)
+	record.SpanID = popString(enc.Fields, SpanIDLogKey)
+	if len(enc.Fields) > 0 {
+		record.Fields = enc.Fields
+	}
+	return record
+}
+
+// popString removes the named string value from the map, returning it.
+func popString(m map[string]any, key string) string {
+	v, _ := m[key].(string)
+	delete(m, key)
+	return v
+}
+
+// WebhookConfig describes how to deliver alert records to an HTTP endpoint.
+type WebhookConfig struct {
+	URL          string            // Endpoint to POST the JSON alert records to
+	Headers      map[string]string // Extra request headers (i.e. Authorization)
+	Timeout      time.Duration     // Timeout for each attempt (default: 5 seconds)
+	MaxRetries   int               // Number of retries after a failed attempt
+	RetryBackoff time.Duration     // Delay before the first retry, doubling each time (default: 1 second)
+	Client       *http.Client      // HTTP client to use (default: http.DefaultClient)
+}
+
+// WebhookAlertHandler returns an alert handler which POSTs each record as JSON to the configured URL,
+// retrying on network errors, 429 and 5xx responses.
+func WebhookAlertHandler(cfg WebhookConfig) AlertFunc {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if cfg.Client == nil {
+		cfg.Client = http.DefaultClient
+	}
+	return func(record AlertRecord) {
+		payload, err := json.Marshal(record)
+		if err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to encode alert for %v: %v\n", cfg.URL, err)
+			return
+		}
+		backoff := cfg.RetryBackoff
+		for attempt := 0; ; attempt++ {
+			retry, err := postWebhook(cfg, payload)
+			if err == nil {
+				return
+			}
+			if !retry || attempt >= cfg.MaxRetries {
+				_, _ = fmt.Fprintf(os.Stderr, "Warning: failed to send alert to %v: %v\n", cfg.URL, err)
+				return
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+}
+
+// postWebhook sends a single webhook request, reporting whether a failure is worth retrying.
+func postWebhook(cfg WebhookConfig, payload []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		return retry, fmt.Errorf("unexpected response status: %v", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAlertHook(t *testing.T) {
+	if err := NewProdLogger("stdout"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	alerts := make(chan AlertRecord, 10)
+	err := AddAlertHook(AlertHook{Name: "test", MinLevel: zapcore.ErrorLevel, Handler: func(r AlertRecord) { alerts <- r }})
+	if err != nil {
+		t.Fatalf("unexpected error adding alert hook: %v", err)
+	}
+	if err = AddAlertHook(AlertHook{Name: "test", Handler: func(AlertRecord) {}}); err == nil {
+		t.Errorf("expected an error adding a duplicate alert hook")
+	}
+	ctx := WithFields(context.Background(), zap.String(RequestIDLogKey, "req-1"), zap.String(TraceIDLogKey, "trace-1"))
+	Ctx(ctx).Warn("Warning should not alert")
+	Ctx(ctx).Error("Error should alert", zap.Int("attempt", 3))
+	if err = RemoveAlertHook("test"); err != nil { // Waits for queued alerts to be delivered
+		t.Fatalf("unexpected error removing alert hook: %v", err)
+	}
+	if err = RemoveAlertHook("test"); err == nil {
+		t.Errorf("expected an error removing a missing alert hook")
+	}
+	L.Error("Error after removal")
+	close(alerts)
+	var records []AlertRecord
+	for r := range alerts {
+		records = append(records, r)
+	}
+	if len(records) != 1 {
+		t.Fatalf("expected 1 alert, got %d: %+v", len(records), records)
+	}
+	r := records[0]
+	if r.Message != "Error should alert" || r.Level != "error" || r.RequestID != "req-1" || r.TraceID != "trace-1" {
+		t.Errorf("unexpected alert record: %+v", r)
+	}
+	if r.Fields["attempt"] != int64(3) {
+		t.Errorf("expected the entry fields in the alert record: %+v", r.Fields)
+	}
+}
+
+func TestAlertHookRateLimit(t *testing.T) {
+	if err := NewProdLogger("stdout"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	var count atomic.Int32
+	err := AddAlertHook(AlertHook{Name: "limited", MinLevel: zapcore.WarnLevel, RateLimit: 2, RateWindow: time.Hour,
+		Handler: func(AlertRecord) { count.Add(1) }})
+	if err != nil {
+		t.Fatalf("unexpected error adding alert hook: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		L.Warn("Rate limited warning")
+	}
+	_ = RemoveAlertHook("limited")
+	if count.Load() != 2 {
+		t.Errorf("expected 2 alerts, got %d", count.Load())
+	}
+	if err = AddAlertHook(AlertHook{Name: "no-handler"}); err == nil {
+		t.Errorf("expected an error adding a hook without a handler")
+	}
+	if err = AddAlertHook(AlertHook{Handler: func(AlertRecord) {}}); err == nil {
+		t.Errorf("expected an error adding a hook without a name")
+	}
+}
+
+func TestAlertHookRemovedWhileDispatching(t *testing.T) {
+	if err := NewProdLogger("stdout"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	var count atomic.Int32
+	if err := AddAlertHook(AlertHook{Name: "removed", MinLevel: zapcore.ErrorLevel, Handler: func(AlertRecord) { count.Add(1) }}); err != nil {
+		t.Fatalf("unexpected error adding alert hook: %v", err)
+	}
+	h := alertHooks.snapshot()[0] // Stale reference, as held by an in-flight write
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				h.dispatch(AlertRecord{Message: "concurrent alert"})
+			}
+		}()
+	}
+	if err := RemoveAlertHook("removed"); err != nil {
+		t.Fatalf("unexpected error removing alert hook: %v", err)
+	}
+	wg.Wait()
+	delivered := count.Load()
+	h.dispatch(AlertRecord{Message: "alert after removal"}) // Should neither panic nor be delivered
+	if count.Load() != delivered {
+		t.Errorf("did not expect alerts to be delivered after removal")
+	}
+}
+
+func TestWebhookAlertHandler(t *testing.T) {
+	var attempts atomic.Int32
+	received := make(chan AlertRecord, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable) // Fail the first attempt to force a retry
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var record AlertRecord
+		if err := json.NewDecoder(r.Body).Decode(&record); err == nil {
+			received <- record
+		}
+	}))
+	defer server.Close()
+	handler := WebhookAlertHandler(WebhookConfig{
+		URL:          server.URL,
+		Headers:      map[string]string{"Authorization": "Bearer token"},
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+	handler(AlertRecord{Level: "dpanic", Message: "webhook alert", RequestID: "req-2"})
+	select {
+	case record := <-received:
+		if record.Message != "webhook alert" || record.RequestID != "req-2" {
+			t.Errorf("unexpected webhook payload: %+v", record)
+		}
+	default:
+		t.Fatalf("expected the webhook to receive the alert")
+	}
+	if attempts.Load() != 2 {
+		t.Errorf("expected 2 webhook attempts, got %d", attempts.Load())
+	}
+	WebhookAlertHandler(WebhookConfig{URL: server.URL + "/bad\x7f"})(AlertRecord{}) // Should not panic
+}
@@ -0,0 +1,145 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Service identity field names, used to select the fields emitted (see Identity).
+const (
+	IdentityService   = "service"       // Service name (supplied by the caller)
+	IdentityEnv       = "env"           // Deployment environment (supplied by the caller)
+	IdentityModule    = "module"        // Main module path (from the build info)
+	IdentityVersion   = "version"       // Main module version (from the build info, unless supplied)
+	IdentityRevision  = "vcs.revision"  // VCS revision the binary was built from
+	IdentityModified  = "vcs.modified"  // Whether the VCS working tree was dirty at build time
+	IdentityHost      = "host"          // Hostname
+	IdentityPID       = "pid"           // Process ID
+	IdentityPod       = "k8s.pod"       // Kubernetes pod name (downward API)
+	IdentityNamespace = "k8s.namespace" // Kubernetes namespace (downward API)
+	IdentityNode      = "k8s.node"      // Kubernetes node name (downward API)
+)
+
+// Kubernetes downward API environment variables used to populate the pod identity fields.
+const (
+	PodNameEnv      = "POD_NAME"
+	PodNamespaceEnv = "POD_NAMESPACE"
+	NodeNameEnv     = "NODE_NAME"
+)
+
+// identityFieldNames lists every supported identity field, in the order they're documented.
+var identityFieldNames = []string{
+	IdentityService, IdentityEnv, IdentityModule, IdentityVersion, IdentityRevision, IdentityModified,
+	IdentityHost, IdentityPID, IdentityPod, IdentityNamespace, IdentityNode,
+}
+
+var readBuildInfo = debug.ReadBuildInfo // Overridden by tests
+
+// Identity requests the automatic population of service identity fields on every log entry.
+// Fields which are unavailable (i.e. not running in Kubernetes) are omitted, and initialFields take precedence.
+type Identity struct {
+	Service string   `json:"service,omitempty" yaml:"service,omitempty"` // Service name
+	Env     string   `json:"env,omitempty" yaml:"env,omitempty"`         // Deployment environment (i.e. prod)
+	Version string   `json:"version,omitempty" yaml:"version,omitempty"` // Overrides the version from the build info
+	Fields  []string `json:"fields,omitempty" yaml:"fields,omitempty"`   // Fields to emit (default: all)
+}
+
+// identityFields gathers the values of the selected identity fields.
+func identityFields(id Identity) (map[string]interface{}, error) {
+	for _, name := range id.Fields {
+		if !slices.Contains(identityFieldNames, name) {
+			return nil, fmt.Errorf("unknown identity field '%v' (expected one of: %v)", name, strings.Join(identityFieldNames, ", "))
+		}
+	}
+	all := map[string]interface{}{
+		IdentityService:   id.Service,
+		IdentityEnv:       id.Env,
+		IdentityPod:       os.Getenv(PodNameEnv),
+		IdentityNamespace: os.Getenv(PodNamespaceEnv),
+		IdentityNode:      os.Getenv(NodeNameEnv),
+		IdentityPID:       os.Getpid(),
+	}
+	if host, err := os.Hostname(); err == nil {
+		all[IdentityHost] = host
+	}
+	if info, ok := readBuildInfo(); ok && info != nil {
+		all[IdentityModule] = info.Main.Path
+		if info.Main.Version != "(devel)" {
+			all[IdentityVersion] = info.Main.Version
+		}
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				all[IdentityRevision] = setting.Value
+			case "vcs.modified":
+				if modified, err := strconv.ParseBool(setting.Value); err == nil {
+					all[IdentityModified] = modified
+				}
+			}
+		}
+	}
+	if len(id.Version) > 0 {
+		all[IdentityVersion] = id.Version
+	}
+	selected := id.Fields
+	if len(selected) == 0 {
+		selected = identityFieldNames
+	}
+	fields := make(map[string]interface{}, len(selected))
+	for _, name := range selected {
+		if value, ok := all[name]; ok && value != "" {
+			fields[name] = value
+		}
+	}
+	return fields, nil
+}
+
+// applyIdentity adds the configured identity fields to the initial fields, using the key names of the configured schema.
+func applyIdentity(cfg *Config) error {
+	if cfg.Identity == nil {
+		return nil
+	}
+	id, err := identityFields(*cfg.Identity)
+	if err != nil {
+		return err
+	}
+	keys := logSchemas[normaliseSchema(cfg.Schema)].identityKeys
+	fields := make(map[string]interface{}, len(cfg.InitialFields)+len(id))
+	for name, value := range id {
+		if key, ok := keys[name]; ok {
+			name = key
+		}
+		fields[name] = value
+	}
+	maps.Copy(fields, cfg.InitialFields) // Don't modify the caller's map & let their fields win
+	cfg.InitialFields = fields
+	return nil
+}
@@ -0,0 +1,141 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// stubBuildInfo replaces the build info for the duration of the test.
+func stubBuildInfo(t *testing.T) {
+	t.Helper()
+	orig := readBuildInfo
+	t.Cleanup(func() { readBuildInfo = orig })
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "github.com/scanoss/test-service", Version: "v1.2.3"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+}
+
+// logIdentityMessage logs the message used by the identity tests.
+func logIdentityMessage(l *zap.Logger) {
+	l.Info("Identity message")
+}
+
+func TestIdentityAllFields(t *testing.T) {
+	stubBuildInfo(t)
+	t.Setenv(PodNameEnv, "pod-1")
+	t.Setenv(PodNamespaceEnv, "scanoss")
+	t.Setenv(NodeNameEnv, "")
+	entry := logJSONEntry(t, []Option{WithIdentity(Identity{Service: "api", Env: "prod"})}, logIdentityMessage)
+	host, _ := os.Hostname()
+	expected := map[string]interface{}{
+		IdentityService:   "api",
+		IdentityEnv:       "prod",
+		IdentityModule:    "github.com/scanoss/test-service",
+		IdentityVersion:   "v1.2.3",
+		IdentityRevision:  "abc123",
+		IdentityModified:  true,
+		IdentityHost:      host,
+		IdentityPID:       float64(os.Getpid()),
+		IdentityPod:       "pod-1",
+		IdentityNamespace: "scanoss",
+	}
+	checkEntryFields(t, entry, expected)
+	if _, ok := entry[IdentityNode]; ok {
+		t.Errorf("did not expect an empty node field, got: %v", entry)
+	}
+}
+
+func TestIdentitySelectedFields(t *testing.T) {
+	stubBuildInfo(t)
+	identity := Identity{Service: "api", Version: "v2.0.0", Fields: []string{IdentityService, IdentityVersion}}
+	entry := logJSONEntry(t, []Option{WithIdentity(identity)}, logIdentityMessage)
+	if entry[IdentityService] != "api" || entry[IdentityVersion] != "v2.0.0" {
+		t.Errorf("expected the selected fields (with the version override), got: %v", entry)
+	}
+	for _, k := range []string{IdentityHost, IdentityPID, IdentityModule, IdentityRevision} {
+		if _, ok := entry[k]; ok {
+			t.Errorf("did not expect unselected field %v, got: %v", k, entry)
+		}
+	}
+}
+
+func TestIdentitySchemaKeys(t *testing.T) {
+	stubBuildInfo(t)
+	identity := Identity{Service: "api", Fields: []string{IdentityService, IdentityPID}}
+	entry := logJSONEntry(t, []Option{WithIdentity(identity), WithSchema(SchemaECS)}, logIdentityMessage)
+	if entry["service.name"] != "api" || entry["process.pid"] != float64(os.Getpid()) {
+		t.Errorf("expected ECS identity keys, got: %v", entry)
+	}
+}
+
+func TestIdentityInitialFieldsWin(t *testing.T) {
+	cfg := ProdConfig(0)
+	cfg.InitialFields = map[string]interface{}{IdentityService: "explicit"}
+	cfg.Identity = &Identity{Service: "auto"}
+	if err := applyIdentity(&cfg); err != nil {
+		t.Fatalf("unexpected error applying identity: %v", err)
+	}
+	if cfg.InitialFields[IdentityService] != "explicit" {
+		t.Errorf("expected the initial field to take precedence, got: %v", cfg.InitialFields)
+	}
+	cfg.Identity = &Identity{Fields: []string{"hostname"}}
+	if err := applyIdentity(&cfg); err == nil {
+		t.Errorf("expected an error from an unknown identity field")
+	}
+}
+
+func TestIdentityConfigFile(t *testing.T) {
+	t.Setenv("TEST_LOG_ENV", "staging")
+	if err := ValidateConfigFile("./tests/zap_config-identity.json"); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	cfg, err := LoadConfig("./tests/zap_config-identity.json")
+	if err != nil {
+		t.Fatalf("unexpected error reading config: %v", err)
+	}
+	if cfg.Identity == nil || cfg.Identity.Service != "test-service" || cfg.Identity.Env != "staging" {
+		t.Errorf("unexpected identity: %+v", cfg.Identity)
+	}
+	invalid := filepath.Join(t.TempDir(), "identity.json")
+	if err = os.WriteFile(invalid, []byte(`{"level": "info", "encoding": "json", "identity": {"fields": ["hostname"]}}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	var validationErr *ConfigValidationError
+	if err = ValidateConfigFile(invalid); !errors.As(err, &validationErr) || validationErr.Problems[0].Path != "identity.fields[0]" {
+		t.Errorf("expected an unknown identity field problem, got: %v", err)
+	}
+}
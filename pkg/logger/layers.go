@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+)
+
+// envRefPattern matches ${VAR} and ${VAR:-default} environment variable references.
+var envRefPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?}`)
+
+// unionKeys are the top-level lists which are combined (rather than replaced) when merging config files.
+var unionKeys = []string{"outputPaths", "errorOutputPaths"}
+
+// LoadConfig reads and merges the supplied JSON config files (in order), returning the resulting config.
+// See EffectiveConfig for details of the merge.
+func LoadConfig(filenames ...string) (Config, error) {
+	var cfg Config
+	merged, err := mergeConfigFiles(filenames)
+	if err != nil {
+		return cfg, err
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to encode merged logging config: %v", err)
+	}
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse logging config json file(s) %v: %v", filenames, err)
+	}
+	return cfg, nil
+}
+
+// EffectiveConfig returns the fully merged JSON config produced from the supplied files, for inspection.
+// Later files are deep merged on top of earlier ones (i.e. logging.base.json followed by logging.prod.json):
+// objects (encoderConfig, initialFields, etc.) are merged key by key, outputPaths/errorOutputPaths are combined,
+// and everything else is replaced. A null value removes a setting inherited from an earlier file.
+// String values may reference environment variables using ${VAR} or ${VAR:-default}.
+func EffectiveConfig(filenames ...string) ([]byte, error) {
+	merged, err := mergeConfigFiles(filenames)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode merged logging config: %v", err)
+	}
+	return data, nil
+}
+
+// mergeConfigFiles reads, expands and deep merges the supplied config files.
+func mergeConfigFiles(filenames []string) (map[string]interface{}, error) {
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no logging config filename provided")
+	}
+	merged := make(map[string]interface{})
+	for _, filename := range filenames {
+		layer, err := readConfigLayer(filename)
+		if err != nil {
+			return nil, err
+		}
+		mergeConfigLayer(merged, layer, true)
+	}
+	return merged, nil
+}
+
+// readConfigLayer loads a single JSON config file, expanding any environment variable references.
+func readConfigLayer(filename string) (map[string]interface{}, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("no logging config filename provided")
+	}
+	byteArray, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logging config file '%v': %v", filename, err)
+	}
+	layer, err := decodeConfigLayer(byteArray)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse logging config json file '%v': %v", filename, err)
+	}
+	return layer, nil
+}
+
+// decodeConfigLayer decodes a JSON config object, expanding any environment variable references.
+func decodeConfigLayer(data []byte) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // Keep numbers exactly as written
+	var layer map[string]interface{}
+	if err := dec.Decode(&layer); err != nil {
+		return nil, err
+	}
+	if layer == nil {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+	expandConfigEnv(layer)
+	return layer, nil
+}
+
+// mergeConfigLayer deep merges the src layer into dst.
+func mergeConfigLayer(dst, src map[string]interface{}, topLevel bool) {
+	for key, value := range src {
+		if value == nil {
+			delete(dst, key)
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if existing, ok := dst[key].(map[string]interface{}); ok {
+				mergeConfigLayer(existing, v, false)
+				continue
+			}
+		case []interface{}:
+			if existing, ok := dst[key].([]interface{}); ok && topLevel && slices.Contains(unionKeys, key) {
+				for _, item := range v {
+					if !slices.Contains(existing, item) {
+						existing = append(existing, item)
+					}
+				}
+				dst[key] = existing
+				continue
+			}
+		}
+		dst[key] = value
+	}
+}
+
+// expandConfigEnv replaces environment variable references in all the string values of the decoded config.
+func expandConfigEnv(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return expandEnv(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = expandConfigEnv(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = expandConfigEnv(item)
+		}
+	}
+	return value
+}
+
+// expandEnv replaces ${VAR} and ${VAR:-default} references with the value of the environment variable.
+// The default is used if the variable is unset or empty.
+func expandEnv(s string) string {
+	return envRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		match := envRefPattern.FindStringSubmatch(ref)
+		if value := os.Getenv(match[1]); len(value) > 0 {
+			return value
+		}
+		return match[2]
+	})
+}
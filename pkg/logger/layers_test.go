@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoadConfigLayers(t *testing.T) {
+	t.Setenv("TEST_LOG_ENV", "production")
+	t.Setenv("TEST_LOG_REGION", "")
+	cfg, err := LoadConfig("./tests/zap_config-base.json", "./tests/zap_config-prod.json")
+	if err != nil {
+		t.Fatalf("unexpected error loading layered config: %v", err)
+	}
+	if cfg.Level.String() != "warn" {
+		t.Errorf("expected the overlay level, got: %v", cfg.Level)
+	}
+	if !slices.Equal(cfg.OutputPaths, []string{"stdout", "stderr"}) {
+		t.Errorf("expected the outputs to be combined, got: %v", cfg.OutputPaths)
+	}
+	expectedFields := map[string]interface{}{"service": "prod", "region": "eu", "env": "production"}
+	for k, v := range expectedFields {
+		if cfg.InitialFields[k] != v {
+			t.Errorf("expected initial field %v=%v, got: %v", k, v, cfg.InitialFields[k])
+		}
+	}
+	enc := cfg.EncoderConfig
+	if enc.MessageKey != "message" || enc.CallerKey != "caller" || len(enc.TimeKey) != 0 {
+		t.Errorf("expected the encoder config to be deep merged, got: %+v", enc)
+	}
+	if err = NewSugaredLoggerFromFile("./tests/zap_config-base.json", "./tests/zap_config-prod.json"); err != nil {
+		t.Errorf("unexpected error creating layered logger: %v", err)
+	}
+	if currentLevel().Level().String() != "warn" {
+		t.Errorf("expected the logger to use the overlay level, got: %v", currentLevel().Level())
+	}
+}
+
+func TestEffectiveConfig(t *testing.T) {
+	t.Setenv("TEST_LOG_LEVEL", "debug")
+	data, err := EffectiveConfig("./tests/zap_config-base.json")
+	if err != nil {
+		t.Fatalf("unexpected error getting effective config: %v", err)
+	}
+	var merged map[string]interface{}
+	if err = json.Unmarshal(data, &merged); err != nil {
+		t.Fatalf("failed to parse effective config '%s': %v", data, err)
+	}
+	if merged["level"] != "debug" {
+		t.Errorf("expected the level to be substituted from the environment, got: %v", merged["level"])
+	}
+	if _, err = EffectiveConfig(); err == nil {
+		t.Errorf("expected an error with no config files")
+	}
+	if _, err = EffectiveConfig("./tests/zap_config.json", "./tests/does-not-exist.json"); err == nil {
+		t.Errorf("expected an error from a missing overlay")
+	}
+	if _, err = EffectiveConfig("./tests/zap_config-broken.json"); err == nil {
+		t.Errorf("expected an error from a broken config file")
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("TEST_LOG_SET", "value")
+	t.Setenv("TEST_LOG_EMPTY", "")
+	tests := map[string]string{
+		"${TEST_LOG_SET}":                "value",
+		"${TEST_LOG_SET:-default}":       "value",
+		"${TEST_LOG_EMPTY:-default}":     "default",
+		"${TEST_LOG_UNSET_VAR}":          "",
+		"pre-${TEST_LOG_SET}-post":       "pre-value-post",
+		"$TEST_LOG_SET":                  "$TEST_LOG_SET",
+		"${TEST_LOG_UNSET_VAR:-a b:c}":   "a b:c",
+		"${TEST_LOG_SET}${TEST_LOG_SET}": "valuevalue",
+	}
+	for in, expected := range tests {
+		if got := expandEnv(in); got != expected {
+			t.Errorf("expandEnv(%q): expected %q, got %q", in, expected, got)
+		}
+	}
+}
+
+func TestMergeConfigLayerNull(t *testing.T) {
+	dst := map[string]interface{}{"sampling": map[string]interface{}{"initial": 100}, "routes": []interface{}{"a"}}
+	mergeConfigLayer(dst, map[string]interface{}{"sampling": nil, "routes": []interface{}{"b"}}, true)
+	if _, ok := dst["sampling"]; ok {
+		t.Errorf("expected null to remove the inherited setting, got: %v", dst)
+	}
+	if routes, _ := dst["routes"].([]interface{}); len(routes) != 1 || routes[0] != "b" {
+		t.Errorf("expected routes to be replaced, got: %v", dst["routes"])
+	}
+}
+
+func TestValidateConfigFileOverlays(t *testing.T) {
+	t.Setenv("TEST_LOG_LEVEL", "")
+	if err := ValidateConfigFile("./tests/zap_config-base.json", "./tests/zap_config-prod.json"); err != nil {
+		t.Errorf("unexpected error validating layered config: %v", err)
+	}
+	t.Setenv("TEST_LOG_LEVEL", "verbose")
+	err := ValidateConfigFile("./tests/zap_config-base.json", "./tests/zap_config-invalid.json")
+	var validationErr *ConfigValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error, got: %v", err)
+	}
+	if validationErr.Filename != "./tests/zap_config-base.json" || len(validationErr.Problems) != 1 {
+		t.Errorf("expected a single problem with the substituted base level, got: %v", validationErr)
+	}
+}
+
+func TestAppConfigOverlays(t *testing.T) {
+	cfg := AppConfig{Mode: "prod", ConfigFile: " ./tests/zap_config-base.json, ./tests/zap_config-prod.json ", Strict: true}
+	if files := cfg.ConfigFileList(); len(files) != 2 || files[1] != "./tests/zap_config-prod.json" {
+		t.Errorf("unexpected config file list: %v", files)
+	}
+	if err := cfg.Apply(); err != nil {
+		t.Errorf("unexpected error applying layered config: %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	TruncatedLogKey        = "truncated"      // Field added to entries which have been truncated
+	DefaultTruncatedMarker = "...[truncated]" // Marker appended to truncated values
+)
+
+// Limits restricts the size of log entries, truncating anything larger. Zero values are unlimited.
+// String and array limits apply to top-level fields (including those added with With).
+type Limits struct {
+	MaxMessageLength int    `json:"maxMessageLength,omitempty" yaml:"maxMessageLength,omitempty"` // Maximum message length (bytes)
+	MaxStringLength  int    `json:"maxStringLength,omitempty" yaml:"maxStringLength,omitempty"`   // Maximum length of string fields (bytes)
+	MaxArrayLength   int    `json:"maxArrayLength,omitempty" yaml:"maxArrayLength,omitempty"`     // Maximum number of array/slice elements
+	MaxEntrySize     int    `json:"maxEntrySize,omitempty" yaml:"maxEntrySize,omitempty"`         // Maximum (estimated) encoded size of an entry (bytes)
+	Marker           string `json:"marker,omitempty" yaml:"marker,omitempty"`                     // Truncation marker (default: ...[truncated])
+}
+
+// enabled reports whether any limits are set.
+func (l Limits) enabled() bool {
+	return l.MaxMessageLength > 0 || l.MaxStringLength > 0 || l.MaxArrayLength > 0 || l.MaxEntrySize > 0
+}
+
+// limitCore truncates entries before passing them to the wrapped core.
+type limitCore struct {
+	zapcore.Core
+	limits      Limits
+	contextSize int  // Estimated encoded size of the fields added with With
+	truncated   bool // Whether any of the fields added with With were truncated
+}
+
+// newLimitCore wraps the given core with the supplied size limits.
+func newLimitCore(core zapcore.Core, limits Limits) zapcore.Core {
+	if !limits.enabled() {
+		return core
+	}
+	if len(limits.Marker) == 0 {
+		limits.Marker = DefaultTruncatedMarker
+	}
+	return &limitCore{Core: core, limits: limits}
+}
+
+// With truncates the fields before adding them to the wrapped core.
+func (c *limitCore) With(fields []zapcore.Field) zapcore.Core {
+	fields, truncated := c.limitFields(fields)
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	clone.truncated = c.truncated || truncated
+	if c.limits.MaxEntrySize > 0 {
+		clone.contextSize += encodedSize(zapcore.Entry{}, fields)
+	}
+	return &clone
+}
+
+// Check runs the wrapped core's own checks (i.e. level, sampling), adding a writer which truncates the entry.
+func (c *limitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	inner := c.Core.Check(ent, nil)
+	if inner == nil {
+		return ce
+	}
+	return ce.AddCore(ent, &limitWriter{limitCore: c, inner: inner})
+}
+
+// limitWriter truncates the fields of a checked entry before writing it to the wrapped core(s).
+type limitWriter struct {
+	*limitCore
+	inner *zapcore.CheckedEntry
+}
+
+// Write truncates the message & fields (and if necessary the whole entry) and writes it,
+// returning any errors from the wrapped core(s).
+func (w *limitWriter) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var msgTruncated bool
+	ent.Message, msgTruncated = truncateString(ent.Message, w.limits.MaxMessageLength, w.limits.Marker)
+	fields, truncated := w.limitFields(fields)
+	if w.limits.MaxEntrySize > 0 {
+		var entryTruncated bool
+		ent, fields, entryTruncated = w.limitEntry(ent, fields)
+		truncated = truncated || entryTruncated
+	}
+	if truncated || msgTruncated || w.limitCore.truncated {
+		fields = append(fields, zap.Bool(TruncatedLogKey, true))
+	}
+	errs := &writeErrors{}
+	w.inner.Entry = ent // Including the caller & stack added after the checks
+	w.inner.ErrorOutput = errs
+	w.inner.Write(fields...)
+	return errs.err
+}
+
+// writeErrors collects the errors reported by a nested checked entry (via its ErrorOutput), so that they can be returned.
+type writeErrors struct {
+	err error
+}
+
+// Write records the reported error, without its timestamp prefix.
+func (w *writeErrors) Write(p []byte) (int, error) {
+	msg := strings.TrimSpace(string(p))
+	if _, cause, ok := strings.Cut(msg, " write error: "); ok {
+		msg = cause
+	}
+	w.err = multierr.Append(w.err, errors.New(msg))
+	return len(p), nil
+}
+
+// Sync is a no-op.
+func (w *writeErrors) Sync() error {
+	return nil
+}
+
+// limitFields truncates long strings and arrays, reporting whether anything was truncated.
+func (c *limitCore) limitFields(fields []zapcore.Field) ([]zapcore.Field, bool) {
+	var limited []zapcore.Field
+	for i, f := range fields {
+		if lf, ok := c.limitField(f); ok {
+			if limited == nil {
+				limited = slices.Clone(fields)
+			}
+			limited[i] = lf
+		}
+	}
+	if limited == nil {
+		return fields, false
+	}
+	return limited, true
+}
+
+// limitField truncates a single field, if it breaches a limit.
+func (c *limitCore) limitField(f zapcore.Field) (zapcore.Field, bool) {
+	maxStr, maxArr, marker := c.limits.MaxStringLength, c.limits.MaxArrayLength, c.limits.Marker
+	switch f.Type {
+	case zapcore.StringType:
+		if s, truncated := truncateString(f.String, maxStr, marker); truncated {
+			return zap.String(f.Key, s), true
+		}
+	case zapcore.ByteStringType:
+		if b, ok := f.Interface.([]byte); ok {
+			if s, truncated := truncateString(string(b), maxStr, marker); truncated {
+				return zap.ByteString(f.Key, []byte(s)), true
+			}
+		}
+	case zapcore.StringerType:
+		if s, truncated := truncateString(fmt.Sprint(f.Interface), maxStr, marker); truncated {
+			return zap.String(f.Key, s), true
+		}
+	case zapcore.ArrayMarshalerType:
+		if arr, ok := f.Interface.(zapcore.ArrayMarshaler); ok && maxArr > 0 {
+			if elements := arrayElements(arr); len(elements) > maxArr {
+				return zap.Array(f.Key, limitedArray{elements: elements[:maxArr], dropped: len(elements) - maxArr}), true
+			}
+		}
+	case zapcore.ReflectType:
+		if v := reflect.ValueOf(f.Interface); maxArr > 0 && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Len() > maxArr {
+			return zap.Any(f.Key, v.Slice(0, maxArr).Interface()), true
+		}
+	case zapcore.UnknownType, zapcore.BinaryType, zapcore.BoolType, zapcore.Complex128Type, zapcore.Complex64Type,
+		zapcore.DurationType, zapcore.Float64Type, zapcore.Float32Type, zapcore.Int64Type, zapcore.Int32Type,
+		zapcore.Int16Type, zapcore.Int8Type, zapcore.TimeType, zapcore.TimeFullType, zapcore.Uint64Type,
+		zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType, zapcore.NamespaceType,
+		zapcore.ErrorType, zapcore.SkipType, zapcore.InlineMarshalerType, zapcore.ObjectMarshalerType:
+	}
+	return f, false
+}
+
+// limitEntry drops the largest fields (and then truncates the message) until the entry fits within MaxEntrySize.
+func (c *limitCore) limitEntry(ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field, bool) {
+	maxSize := c.limits.MaxEntrySize
+	total := c.contextSize + encodedSize(ent, fields)
+	if total <= maxSize {
+		return ent, fields, false
+	}
+	fields = slices.Clone(fields)
+	sizes := make([]int, len(fields))
+	for i, f := range fields {
+		sizes[i] = encodedSize(zapcore.Entry{}, []zapcore.Field{f})
+	}
+	for total > maxSize {
+		largest := -1
+		for i := range fields {
+			if sizes[i] > len(c.limits.Marker)+len(fields[i].Key)+8 && (largest < 0 || sizes[i] > sizes[largest]) {
+				largest = i
+			}
+		}
+		if largest < 0 {
+			break // Nothing left worth dropping
+		}
+		fields[largest] = zap.String(fields[largest].Key, c.limits.Marker)
+		newSize := encodedSize(zapcore.Entry{}, fields[largest:largest+1])
+		total -= sizes[largest] - newSize
+		sizes[largest] = newSize
+	}
+	if over := total - maxSize; over > 0 {
+		ent.Message, _ = truncateString(ent.Message, max(len(ent.Message)-over-len(c.limits.Marker), 0), c.limits.Marker)
+	}
+	return ent, fields, true
+}
+
+// sizeEncoderConfig is used to estimate the encoded size of entries and fields.
+var sizeEncoderConfig = zapcore.EncoderConfig{
+	MessageKey:     "msg",
+	EncodeDuration: zapcore.StringDurationEncoder,
+	EncodeTime:     zapcore.ISO8601TimeEncoder,
+}
+
+// encodedSize estimates the JSON encoded size of the entry message and fields.
+func encodedSize(ent zapcore.Entry, fields []zapcore.Field) int {
+	buf, err := zapcore.NewJSONEncoder(sizeEncoderConfig).EncodeEntry(ent, fields)
+	if err != nil {
+		return 0
+	}
+	defer buf.Free()
+	return buf.Len()
+}
+
+// truncateString shortens the string to at most maxLen bytes (on a UTF-8 boundary), appending the marker.
+func truncateString(s string, maxLen int, marker string) (string, bool) {
+	if maxLen <= 0 || len(s) <= maxLen {
+		return s, false
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + marker, true
+}
+
+// limitedArray only encodes the first max elements of the wrapped array.
+type limitedArray struct {
+	elements []interface{}
+	dropped  int
+}
+
+// MarshalLogArray encodes the kept elements of the array, followed by a marker describing the dropped elements.
+func (a limitedArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, e := range a.elements {
+		if err := enc.AppendReflected(e); err != nil {
+			return err
+		}
+	}
+	enc.AppendString(fmt.Sprintf("...[%d more]", a.dropped))
+	return nil
+}
+
+// arrayElements returns the top-level elements of the array, as encoded by a map encoder.
+func arrayElements(arr zapcore.ArrayMarshaler) []interface{} {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddArray("a", arr); err != nil {
+		return nil
+	}
+	elements, _ := enc.Fields["a"].([]interface{})
+	return elements
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLimitsMessageAndStrings(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithLimits(Limits{MaxMessageLength: 10, MaxStringLength: 5})}, func(l *zap.Logger) {
+		l.With(zap.String("ctx", "context-value")).Info("A very long log message",
+			zap.String("short", "abc"), zap.String("long", "abcdefghij"), zap.ByteString("bytes", []byte("0123456789")))
+	})
+	expected := map[string]interface{}{
+		"msg":           "A very lon" + DefaultTruncatedMarker,
+		"short":         "abc",
+		"long":          "abcde" + DefaultTruncatedMarker,
+		"bytes":         "01234" + DefaultTruncatedMarker,
+		"ctx":           "conte" + DefaultTruncatedMarker,
+		TruncatedLogKey: true,
+	}
+	checkEntryFields(t, entry, expected)
+}
+
+func TestLimitsNotTruncated(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithLimits(Limits{MaxMessageLength: 100, MaxStringLength: 100, MaxArrayLength: 5, MaxEntrySize: 1024})}, func(l *zap.Logger) {
+		l.Info("Short message", zap.String("key", "value"), zap.Ints("ints", []int{1, 2, 3}))
+	})
+	if _, ok := entry[TruncatedLogKey]; ok {
+		t.Errorf("did not expect a truncated field, got: %v", entry)
+	}
+	if entry["msg"] != "Short message" || entry["key"] != "value" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+}
+
+func TestLimitsArrays(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithLimits(Limits{MaxArrayLength: 3})}, func(l *zap.Logger) {
+		l.Info("Arrays", zap.Ints("ints", []int{1, 2, 3, 4, 5}), zap.Reflect("slice", []string{"a", "b", "c", "d"}))
+	})
+	ints, _ := entry["ints"].([]interface{})
+	if len(ints) != 4 || ints[0] != float64(1) || ints[3] != "...[2 more]" {
+		t.Errorf("unexpected truncated ints: %v", entry["ints"])
+	}
+	slice, _ := entry["slice"].([]interface{})
+	if len(slice) != 3 {
+		t.Errorf("unexpected truncated slice: %v", entry["slice"])
+	}
+	if entry[TruncatedLogKey] != true {
+		t.Errorf("expected truncated field, got: %v", entry)
+	}
+}
+
+func TestLimitsEntrySize(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithLimits(Limits{MaxEntrySize: 200, Marker: "<cut>"})}, func(l *zap.Logger) {
+		l.Info("Entry size", zap.String("big", strings.Repeat("x", 500)), zap.String("small", "kept"))
+	})
+	if entry["big"] != "<cut>" {
+		t.Errorf("expected the largest field to be dropped, got: %v", entry["big"])
+	}
+	if entry["small"] != "kept" || entry["msg"] != "Entry size" {
+		t.Errorf("unexpected entry: %v", entry)
+	}
+	if entry[TruncatedLogKey] != true {
+		t.Errorf("expected truncated field, got: %v", entry)
+	}
+}
+
+func TestLimitsKeepCallerAndStack(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithLimits(Limits{MaxStringLength: 5})}, func(l *zap.Logger) {
+		l.Error("Truncated error", zap.String("long", "abcdefghij"))
+	})
+	if entry["long"] != "abcde"+DefaultTruncatedMarker {
+		t.Errorf("expected a truncated field, got: %v", entry)
+	}
+	if caller, _ := entry["caller"].(string); !strings.HasPrefix(caller, "logger/limits_test.go:") {
+		t.Errorf("expected the caller to survive truncation, got: %v", entry)
+	}
+	if stack, _ := entry["stacktrace"].(string); !strings.Contains(stack, "TestLimitsKeepCallerAndStack") {
+		t.Errorf("expected the stack trace to survive truncation, got: %v", entry)
+	}
+}
+
+func TestLimitsWriteErrors(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	core := newLimitCore(zapcore.NewCore(enc, zapcore.AddSync(failingWriter{}), zapcore.DebugLevel), Limits{MaxStringLength: 5})
+	ce := core.Check(zapcore.Entry{Level: zapcore.InfoLevel, Message: "Failing write"}, nil)
+	errs := &writeErrors{}
+	ce.ErrorOutput = errs
+	ce.Write(zap.String("long", "abcdefghij"))
+	if errs.err == nil || !strings.Contains(errs.err.Error(), "disk full") {
+		t.Errorf("expected the wrapped core's write error, got: %v", errs.err)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+// Write returns an error.
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestLimitsRuntimeSinksAndAlerts(t *testing.T) {
+	if err := SetupAppLoggerWithOptions("prod", "", false, WithOutputs("stdout"), WithLimits(Limits{MaxStringLength: 5})); err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	sinkLog := filepath.Join(t.TempDir(), "sink.log")
+	if err := AddSink(SinkConfig{Name: "limits", Outputs: []string{sinkLog}}); err != nil {
+		t.Fatalf("unexpected error adding sink: %v", err)
+	}
+	defer func() { _ = RemoveSink("limits") }()
+	alerts := make(chan AlertRecord, 1)
+	if err := AddAlertHook(AlertHook{Name: "limits", MinLevel: zapcore.ErrorLevel, Handler: func(r AlertRecord) { alerts <- r }}); err != nil {
+		t.Fatalf("unexpected error adding alert hook: %v", err)
+	}
+	L.With(zap.String("ctx", "context-value")).Error("Limited", zap.String("long", "abcdefghij"))
+	if err := RemoveAlertHook("limits"); err != nil {
+		t.Fatalf("unexpected error removing alert hook: %v", err)
+	}
+	SyncZap()
+	data, err := os.ReadFile(sinkLog)
+	if err != nil {
+		t.Fatalf("failed to read sink output: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n") // Following the 'Added log sink' message
+	var entry map[string]interface{}
+	if err = json.Unmarshal([]byte(lines[len(lines)-1]), &entry); err != nil {
+		t.Fatalf("failed to parse sink entry '%v': %v", string(data), err)
+	}
+	if entry["long"] != "abcde"+DefaultTruncatedMarker || entry["ctx"] != "conte"+DefaultTruncatedMarker || entry[TruncatedLogKey] != true {
+		t.Errorf("expected a truncated entry in the sink, got: %v", entry)
+	}
+	record := <-alerts
+	if record.Fields["long"] != "abcde"+DefaultTruncatedMarker || record.Fields["ctx"] != "conte"+DefaultTruncatedMarker {
+		t.Errorf("expected a truncated alert record, got: %+v", record.Fields)
+	}
+}
+
+func TestTruncateStringUTF8(t *testing.T) {
+	s, truncated := truncateString("héllo", 2, "~")
+	if !truncated || s != "h~" {
+		t.Errorf("expected truncation on a rune boundary, got: %q", s)
+	}
+	if s, truncated = truncateString("abc", 0, "~"); truncated || s != "abc" {
+		t.Errorf("expected no truncation when unlimited, got: %q", s)
+	}
+}
+
+func TestLimitsConfigFile(t *testing.T) {
+	if err := ValidateConfigFile("./tests/zap_config-limits.json"); err != nil {
+		t.Errorf("unexpected validation error: %v", err)
+	}
+	cfg, err := LoadConfig("./tests/zap_config-limits.json")
+	if err != nil {
+		t.Fatalf("unexpected error reading config: %v", err)
+	}
+	if cfg.Limits == nil || cfg.Limits.MaxMessageLength != 64 || cfg.Limits.MaxEntrySize != 4096 {
+		t.Errorf("unexpected limits: %+v", cfg.Limits)
+	}
+	if err = NewSugaredLoggerFromFile("./tests/zap_config-limits.json"); err != nil {
+		t.Errorf("unexpected error loading config: %v", err)
+	}
+}
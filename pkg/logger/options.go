@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+// Option configures the behaviour of SetupAppLoggerWithOptions.
+type Option func(*appOptions)
+
+// appOptions holds the settings supplied to SetupAppLoggerWithOptions.
+type appOptions struct {
+	outputs  []string     // Log output destinations (stdout/stderr/file)
+	strict   bool         // Validate the config file before loading it
+	dryRun   bool         // Only validate the configuration, don't create a logger
+	crash    string       // File to write fatal runtime errors to
+	routes   []LevelRoute // Per level range outputs
+	schema   string       // Cloud provider log schema
+	limits   *Limits      // Message/field/entry size limits
+	overlays []string     // Config files merged on top of the main config file
+	identity *Identity    // Automatic service identity fields
+}
+
+// WithOutputs sets the output destinations of the logger (stdout/stderr/file).
+func WithOutputs(outputs ...string) Option {
+	return func(o *appOptions) {
+		o.outputs = outputs
+	}
+}
+
+// WithStrictConfig validates the logging config file (see ValidateConfigFile) before loading it,
+// failing with all the problems found, rather than silently ignoring unknown/misspelt settings.
+func WithStrictConfig() Option {
+	return func(o *appOptions) {
+		o.strict = true
+	}
+}
+
+// WithDryRun only validates the logging configuration, without creating/replacing the global loggers.
+func WithDryRun() Option {
+	return func(o *appOptions) {
+		o.dryRun = true
+		o.strict = true
+	}
+}
+
+// WithCrashOutput writes fatal runtime errors (which bypass the logger) to the given file (see SetupCrashOutput).
+func WithCrashOutput(filename string) Option {
+	return func(o *appOptions) {
+		o.crash = filename
+	}
+}
+
+// WithLevelRoutes sends each level range to its own outputs (i.e. errors to a separate file).
+// The routes replace the outputs of the preset or config file.
+func WithLevelRoutes(routes ...LevelRoute) Option {
+	return func(o *appOptions) {
+		o.routes = routes
+	}
+}
+
+// WithSchema formats the logs using a cloud provider log schema: gcp, aws or ecs (Elastic Common Schema).
+func WithSchema(schema string) Option {
+	return func(o *appOptions) {
+		o.schema = schema
+	}
+}
+
+// WithConfigOverlays merges the given config files (in order) on top of the main config file (see EffectiveConfig).
+func WithConfigOverlays(filenames ...string) Option {
+	return func(o *appOptions) {
+		o.overlays = filenames
+	}
+}
+
+// WithLimits truncates log messages, fields and entries which exceed the given size limits.
+func WithLimits(limits Limits) Option {
+	return func(o *appOptions) {
+		o.limits = &limits
+	}
+}
+
+// WithIdentity adds service identity fields (service name, version, VCS revision, host, PID, Kubernetes pod, etc.)
+// to every log entry.
+func WithIdentity(identity Identity) Option {
+	return func(o *appOptions) {
+		o.identity = &identity
+	}
+}
+
+// newAppOptions applies the supplied options on top of the defaults.
+func newAppOptions(opts []Option) appOptions {
+	var o appOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// applyTo overrides the logging config with any settings supplied in the options.
+func (o *appOptions) applyTo(cfg *Config) {
+	if o == nil {
+		return
+	}
+	if len(o.routes) > 0 {
+		cfg.Routes = o.routes
+	}
+	if len(o.schema) > 0 {
+		cfg.Schema = o.schema
+	}
+	if o.limits != nil {
+		cfg.Limits = o.limits
+	}
+	if o.identity != nil {
+		cfg.Identity = o.identity
+	}
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ctxLevelKey struct{} // Used for storing a level override in a context
+
+// WithLevelOverride returns a copy of the context whose context logger (see Ctx/SCtx) logs at the given level,
+// if it is more verbose than the global level. Neither the global level, nor any other request, is affected.
+func WithLevelOverride(ctx context.Context, lvl zapcore.Level) context.Context {
+	return context.WithValue(ctx, ctxLevelKey{}, lvl)
+}
+
+// LevelOverride returns the level override carried by the context, if any.
+func LevelOverride(ctx context.Context) (zapcore.Level, bool) {
+	lvl, ok := ctx.Value(ctxLevelKey{}).(zapcore.Level)
+	return lvl, ok
+}
+
+// OverrideLevel returns a child of the logger which also logs entries at (or above) the given level,
+// without changing the global level. Only loggers created by this package support overrides,
+// any other logger is returned unchanged.
+func OverrideLevel(l *zap.Logger, lvl zapcore.Level) *zap.Logger {
+	if _, ok := overrideCoreLevel(l.Core(), lvl); !ok {
+		return l
+	}
+	return l.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if overridden, ok := overrideCoreLevel(core, lvl); ok {
+			return overridden
+		}
+		return core
+	}))
+}
+
+// levelOverrider is implemented by the cores of this package which can have their level overridden.
+type levelOverrider interface {
+	withLevelOverride(lvl zapcore.Level) zapcore.Core
+}
+
+// overrideCoreLevel returns a copy of the core with the level override applied, if supported.
+func overrideCoreLevel(core zapcore.Core, lvl zapcore.Level) (zapcore.Core, bool) {
+	if o, ok := core.(levelOverrider); ok {
+		return o.withLevelOverride(lvl), true
+	}
+	return core, false
+}
+
+// levelCore applies the global (atomic) level to the main core(s), which are built with a permissive level,
+// so that individual child loggers can be made more verbose.
+type levelCore struct {
+	zapcore.Core
+	level       zapcore.LevelEnabler // Global atomic level
+	override    zapcore.Level        // Per logger minimum level (if hasOverride)
+	hasOverride bool
+}
+
+// newLevelCore gates the given (permissive) core using the supplied level.
+func newLevelCore(core zapcore.Core, level zapcore.LevelEnabler) zapcore.Core {
+	return &levelCore{Core: core, level: level}
+}
+
+// Enabled reports whether the level is enabled by the global level or the override.
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl) || (c.hasOverride && lvl >= c.override)
+}
+
+// Level returns the minimum enabled level.
+func (c *levelCore) Level() zapcore.Level {
+	lvl := zapcore.LevelOf(c.level)
+	if c.hasOverride && c.override < lvl {
+		return c.override
+	}
+	return lvl
+}
+
+// With adds structured context to the wrapped core.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.Core = c.Core.With(fields)
+	return &clone
+}
+
+// Check passes enabled entries on to the wrapped core.
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// withLevelOverride returns a copy of the core which also logs entries at (or above) the given level.
+func (c *levelCore) withLevelOverride(lvl zapcore.Level) zapcore.Core {
+	clone := *c
+	clone.override, clone.hasOverride = lvl, true
+	return &clone
+}
+
+// withLevelOverride applies the level override to the main core.
+func (c *sinkCore) withLevelOverride(lvl zapcore.Level) zapcore.Core {
+	core, _ := overrideCoreLevel(c.Core, lvl)
+	return &sinkCore{Core: core, fields: c.fields}
+}
+
+// withLevelOverride applies the level override to the wrapped core.
+func (c *limitCore) withLevelOverride(lvl zapcore.Level) zapcore.Core {
+	clone := *c
+	clone.Core, _ = overrideCoreLevel(c.Core, lvl)
+	return &clone
+}
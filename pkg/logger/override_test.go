@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// readLogLines returns the non-empty lines written to the given log file.
+func readLogLines(t *testing.T, filename string) []string {
+	t.Helper()
+	SyncZap()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	return strings.Fields(strings.ReplaceAll(strings.TrimSpace(string(data)), " ", ""))
+}
+
+func TestLevelOverride(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "override.log")
+	if err := NewProdLoggerLevel(zapcore.InfoLevel, logFile); err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	ctx := WithLevelOverride(context.Background(), zapcore.DebugLevel)
+	if lvl, ok := LevelOverride(ctx); !ok || lvl != zapcore.DebugLevel {
+		t.Errorf("expected a debug level override, got: %v, %v", lvl, ok)
+	}
+	Ctx(ctx).Debug("override debug")
+	SCtx(ctx).Debugf("override sugared %v", "debug")
+	Ctx(context.Background()).Debug("other debug")
+	Get().Debug("global debug")
+	if !Ctx(ctx).Core().Enabled(zapcore.DebugLevel) || Ctx(ctx).Level() != zapcore.DebugLevel {
+		t.Errorf("expected the context logger to be enabled for debug")
+	}
+	if Get().Core().Enabled(zapcore.DebugLevel) || currentLevel().Level() != zapcore.InfoLevel {
+		t.Errorf("did not expect the global level to change")
+	}
+	lines := readLogLines(t, logFile)
+	if len(lines) != 2 || !strings.Contains(lines[0], "overridedebug") || !strings.Contains(lines[1], "overridesugareddebug") {
+		t.Errorf("expected only the overridden debug messages, got: %v", lines)
+	}
+}
+
+func TestLevelOverrideRoutes(t *testing.T) {
+	dir := t.TempDir()
+	appLog, errLog := filepath.Join(dir, "app.log"), filepath.Join(dir, "errors.log")
+	err := SetupAppLoggerWithOptions("prod", "", false, WithLevelRoutes(
+		LevelRoute{Levels: "<error", Outputs: []string{appLog}},
+		LevelRoute{Levels: ">=error", Outputs: []string{errLog}},
+	))
+	if err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	l := OverrideLevel(Get(), zapcore.DebugLevel)
+	l.Debug("routed debug")
+	l.Error("routed error")
+	if lines := readLogLines(t, appLog); len(lines) != 1 || !strings.Contains(lines[0], "routeddebug") {
+		t.Errorf("expected the debug message in the app log, got: %v", lines)
+	}
+	if lines := readLogLines(t, errLog); len(lines) != 1 || !strings.Contains(lines[0], "routederror") {
+		t.Errorf("expected only the error message in the error log, got: %v", lines)
+	}
+}
+
+func TestLevelOverrideExternalLogger(t *testing.T) {
+	l := zap.NewNop()
+	if OverrideLevel(l, zapcore.DebugLevel) != l {
+		t.Errorf("expected an unsupported logger to be returned unchanged")
+	}
+}
+
+func TestLevelOverrideConcurrent(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "override.log")
+	if err := NewProdLoggerLevel(zapcore.WarnLevel, logFile); err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(debug bool) {
+			defer wg.Done()
+			ctx := context.Background()
+			if debug {
+				ctx = WithLevelOverride(ctx, zapcore.DebugLevel)
+			}
+			for j := 0; j < 20; j++ { // Stay below the prod sampling threshold
+				Ctx(ctx).Info("concurrent")
+			}
+		}(i%2 == 0)
+	}
+	wg.Wait()
+	if lines := readLogLines(t, logFile); len(lines) != 4*20 {
+		t.Errorf("expected only the overridden requests to log, got %d lines", len(lines))
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const maxStackFrames = 64 // Maximum number of stack frames to log for a panic
+
+// RecoverOption configures the behaviour of RecoverAndLog and Go.
+type RecoverOption func(*recoverOptions)
+
+// recoverOptions holds the settings for logging a recovered panic.
+type recoverOptions struct {
+	name    string          // Name of the goroutine
+	ctx     context.Context // Context to extract logging fields from
+	logger  *zap.Logger     // Logger to write the panic to (default: the global logger)
+	level   zapcore.Level   // Level to log the panic at
+	rePanic bool            // Panic again after logging
+}
+
+// WithGoroutineName sets the goroutine name logged along with any panic.
+func WithGoroutineName(name string) RecoverOption {
+	return func(o *recoverOptions) {
+		o.name = name
+	}
+}
+
+// WithRecoverContext adds the logging fields carried by the context (see Ctx) to the panic log message.
+func WithRecoverContext(ctx context.Context) RecoverOption {
+	return func(o *recoverOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithRecoverLogger logs the panic to the given logger (i.e. a request scoped one), rather than the global logger.
+func WithRecoverLogger(l *zap.Logger) RecoverOption {
+	return func(o *recoverOptions) {
+		o.logger = l
+	}
+}
+
+// WithRecoverLevel sets the level to log panics at (default: error).
+// Note: DPanic will itself panic when using a development logger.
+func WithRecoverLevel(lvl zapcore.Level) RecoverOption {
+	return func(o *recoverOptions) {
+		o.level = lvl
+	}
+}
+
+// WithRePanic panics again (with the original value) once the panic has been logged and flushed.
+func WithRePanic() RecoverOption {
+	return func(o *recoverOptions) {
+		o.rePanic = true
+	}
+}
+
+// RecoverAndLog recovers from a panic, logging it with a structured stack trace and flushing the logs.
+// It must be deferred directly: defer logger.RecoverAndLog().
+func RecoverAndLog(opts ...RecoverOption) {
+	if r := recover(); r != nil {
+		logPanic(r, opts)
+	}
+}
+
+// Go runs the given function in a new goroutine, logging (rather than crashing on) any panic.
+func Go(fn func(), opts ...RecoverOption) {
+	go func() {
+		defer RecoverAndLog(opts...)
+		fn()
+	}()
+}
+
+// LogPanic logs a value which has already been recovered from a panic (i.e. by a gRPC/HTTP handler wrapper).
+// It must be called from the deferred function which recovered the panic, so that the panicking stack is still available.
+func LogPanic(r any, opts ...RecoverOption) {
+	logPanic(r, opts)
+}
+
+// logPanic logs the recovered value, flushes the logs and optionally panics again.
+func logPanic(r any, opts []RecoverOption) {
+	o := recoverOptions{level: zapcore.ErrorLevel}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	l := o.logger
+	if l == nil {
+		l = state.Load().logger
+	}
+	if l == nil {
+		l = zap.NewNop()
+		_, _ = fmt.Fprintf(os.Stderr, "panic recovered: %v\n%s\n", r, debug.Stack())
+	} else if o.ctx != nil {
+		l = l.With(Fields(o.ctx)...)
+	}
+	fields := []zap.Field{zap.Any("panic", r), zap.Array("stack", panicStack())}
+	if len(o.name) > 0 {
+		fields = append(fields, zap.String("goroutine", o.name))
+	}
+	if err, ok := r.(error); ok {
+		fields = append(fields, zap.Error(err))
+	}
+	func() {
+		defer func() { _ = l.Sync() }() // Make sure the panic is flushed, even if logging at DPanic panics
+		l.WithOptions(zap.AddStacktrace(zapcore.InvalidLevel)).Log(o.level, fmt.Sprintf("Recovered from panic: %v", r), fields...)
+	}()
+	if o.rePanic {
+		panic(r)
+	}
+}
+
+// stackFrame is a single entry of a structured stack trace.
+type stackFrame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// MarshalLogObject encodes the stack frame as a structured logging object.
+func (f stackFrame) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("function", f.Function)
+	enc.AddString("file", f.File)
+	enc.AddInt("line", f.Line)
+	return nil
+}
+
+// stackFrames is a structured stack trace.
+type stackFrames []stackFrame
+
+// MarshalLogArray encodes the stack trace as a structured logging array.
+func (frames stackFrames) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, f := range frames {
+		if err := enc.AppendObject(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// panicStack returns the stack of the panicking goroutine, starting at the frame that panicked.
+func panicStack() stackFrames {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(2, pcs)
+	iter := runtime.CallersFrames(pcs[:n])
+	var frames stackFrames
+	for {
+		frame, more := iter.Next()
+		if frame.Function == "runtime.gopanic" {
+			frames = frames[:0] // Everything so far is the recovery machinery
+		} else if !strings.HasPrefix(frame.Function, "runtime.") {
+			frames = append(frames, stackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// SetupCrashOutput additionally writes fatal runtime errors (i.e. unrecovered panics, concurrent map writes)
+// to the given file, so that they are captured even though they bypass the logger.
+func SetupCrashOutput(filename string) error {
+	if len(filename) == 0 {
+		return fmt.Errorf("no crash output filename provided")
+	}
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open crash output file '%v': %v", filename, err)
+	}
+	defer f.Close() // The runtime keeps its own duplicate of the file descriptor
+	if err = debug.SetCrashOutput(f, debug.CrashOptions{}); err != nil {
+		return fmt.Errorf("failed to set crash output to '%v': %v", filename, err)
+	}
+	return nil
+}
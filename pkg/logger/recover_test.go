@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRecoverAndLog(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	Set(zap.New(core))
+	ctx := WithFields(context.Background(), zap.String(RequestIDLogKey, "1234"))
+	func() {
+		defer RecoverAndLog(WithGoroutineName("worker"), WithRecoverContext(ctx))
+		panic(errors.New("test panic"))
+	}()
+	if logs.Len() != 1 {
+		t.Fatalf("expected 1 log message, got %d", logs.Len())
+	}
+	entry := logs.All()[0]
+	if entry.Level != zapcore.ErrorLevel || !strings.Contains(entry.Message, "test panic") {
+		t.Errorf("unexpected panic log entry: %v - %v", entry.Level, entry.Message)
+	}
+	fields := entry.ContextMap()
+	if fields["goroutine"] != "worker" || fields[RequestIDLogKey] != "1234" || fields["error"] != "test panic" {
+		t.Errorf("unexpected panic log fields: %v", fields)
+	}
+	stack, ok := fields["stack"].([]interface{})
+	if !ok || len(stack) == 0 {
+		t.Fatalf("expected a structured stack, got: %v", fields["stack"])
+	}
+	if frame, _ := stack[0].(map[string]interface{}); !strings.Contains(frame["function"].(string), "TestRecoverAndLog") {
+		t.Errorf("expected the stack to start at the panicking function, got: %v", stack[0])
+	}
+}
+
+func TestLogPanicWithLogger(t *testing.T) {
+	global, globalLogs := observer.New(zap.DebugLevel)
+	Set(zap.New(global))
+	core, logs := observer.New(zap.DebugLevel)
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				LogPanic(r, WithRecoverLogger(zap.New(core).With(zap.String("scope", "request"))))
+			}
+		}()
+		panic("handler panic")
+	}()
+	if globalLogs.Len() != 0 || logs.Len() != 1 {
+		t.Fatalf("expected the panic to only be logged to the supplied logger, got %d/%d", globalLogs.Len(), logs.Len())
+	}
+	fields := logs.All()[0].ContextMap()
+	if fields["scope"] != "request" || fields["panic"] != "handler panic" {
+		t.Errorf("unexpected panic log fields: %v", fields)
+	}
+	stack, ok := fields["stack"].([]interface{})
+	if !ok || len(stack) == 0 {
+		t.Fatalf("expected a structured stack, got: %v", fields["stack"])
+	}
+	if frame, _ := stack[0].(map[string]interface{}); !strings.Contains(frame["function"].(string), "TestLogPanicWithLogger") {
+		t.Errorf("expected the stack to start at the panicking function, got: %v", stack[0])
+	}
+}
+
+func TestRecoverAndLogRePanic(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	Set(zap.New(core))
+	defer func() {
+		if r := recover(); r != "again" {
+			t.Errorf("expected the original panic value to be re-raised, got: %v", r)
+		}
+		if logs.Len() != 1 {
+			t.Errorf("expected the panic to be logged before re-panicking")
+		}
+	}()
+	defer RecoverAndLog(WithRePanic(), WithRecoverLevel(zapcore.WarnLevel))
+	panic("again")
+}
+
+func TestGo(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	Set(zap.New(core))
+	Go(func() {
+		panic("goroutine panic")
+	}, WithGoroutineName("background"))
+	deadline := time.Now().Add(5 * time.Second)
+	for logs.Len() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond) // Wait for the goroutine to panic and be recovered
+	}
+	if logs.FilterField(zap.String("goroutine", "background")).Len() != 1 {
+		t.Errorf("expected the goroutine panic to be logged")
+	}
+}
+
+func TestSetupCrashOutput(t *testing.T) {
+	if err := SetupCrashOutput(""); err == nil {
+		t.Errorf("expected an error from an empty crash output filename")
+	}
+	if err := SetupCrashOutput(filepath.Join(t.TempDir(), "missing", "crash.log")); err == nil {
+		t.Errorf("expected an error from a missing crash output directory")
+	}
+	crashFile := filepath.Join(t.TempDir(), "crash.log")
+	if err := SetupCrashOutput(crashFile); err != nil {
+		t.Fatalf("unexpected error setting up crash output: %v", err)
+	}
+	defer func() { _ = debug.SetCrashOutput(nil, debug.CrashOptions{}) }()
+	if _, err := os.Stat(crashFile); err != nil {
+		t.Errorf("expected crash output file to be created: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelRoute sends all log entries within a level range to its own outputs.
+type LevelRoute struct {
+	Levels  string   `json:"levels" yaml:"levels"`   // Level range: i.e. ">=warn", "<warn", "<=info", ">debug", "=error" or "error". Empty for all levels
+	Outputs []string `json:"outputs" yaml:"outputs"` // Output destinations (stdout/stderr/file)
+}
+
+// levelRange is an inclusive range of logging levels.
+type levelRange struct {
+	min zapcore.Level
+	max zapcore.Level
+}
+
+// contains reports whether the level is within the range.
+func (r levelRange) contains(lvl zapcore.Level) bool {
+	return lvl >= r.min && lvl <= r.max
+}
+
+// parseLevelRange parses a level range expression (i.e. ">=warn").
+func parseLevelRange(expr string) (levelRange, error) {
+	r := levelRange{min: zapcore.DebugLevel, max: zapcore.FatalLevel}
+	expr = strings.TrimSpace(expr)
+	if len(expr) == 0 {
+		return r, nil
+	}
+	var op string
+	for _, candidate := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(expr, candidate) {
+			op = candidate
+			break
+		}
+	}
+	lvl, err := zapcore.ParseLevel(strings.TrimSpace(expr[len(op):]))
+	if err != nil {
+		return r, fmt.Errorf("invalid level range '%v': %v", expr, err)
+	}
+	switch op {
+	case ">=":
+		r.min = lvl
+	case ">":
+		r.min = lvl + 1
+	case "<=":
+		r.max = lvl
+	case "<":
+		r.max = lvl - 1
+	default: // Exact match
+		r.min, r.max = lvl, lvl
+	}
+	if r.min > r.max {
+		return r, fmt.Errorf("level range '%v' does not match any levels", expr)
+	}
+	return r, nil
+}
+
+// buildRouteCore creates a core per level route, all sharing the same encoder config and atomic level.
+func buildRouteCore(cfg Config) (zapcore.Core, error) {
+	cores := make([]zapcore.Core, 0, len(cfg.Routes))
+	for i, route := range cfg.Routes {
+		levels, err := parseLevelRange(route.Levels)
+		if err != nil {
+			return nil, fmt.Errorf("route %d: %v", i, err)
+		}
+		if len(route.Outputs) == 0 {
+			return nil, fmt.Errorf("route %d ('%v'): no outputs provided", i, route.Levels)
+		}
+		rc := cfg.Config
+		rc.OutputPaths = route.Outputs
+		l, err := rc.Build()
+		if err != nil {
+			return nil, fmt.Errorf("route %d ('%v'): %v", i, route.Levels, err)
+		}
+		cores = append(cores, &levelRangeCore{Core: l.Core(), levels: levels})
+	}
+	return zapcore.NewTee(cores...), nil
+}
+
+// levelRangeCore only passes on entries within its level range.
+type levelRangeCore struct {
+	zapcore.Core
+	levels levelRange
+}
+
+// Enabled reports whether the level is within range and enabled by the wrapped core.
+func (c *levelRangeCore) Enabled(lvl zapcore.Level) bool {
+	return c.levels.contains(lvl) && c.Core.Enabled(lvl)
+}
+
+// With adds structured context to the wrapped core.
+func (c *levelRangeCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelRangeCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check adds the wrapped core to the checked entry, if the entry is within the level range.
+func (c *levelRangeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.contains(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
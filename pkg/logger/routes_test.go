@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLevelRange(t *testing.T) {
+	tests := []struct {
+		expr     string
+		min, max zapcore.Level
+		wantErr  bool
+	}{
+		{expr: "", min: zapcore.DebugLevel, max: zapcore.FatalLevel},
+		{expr: ">=warn", min: zapcore.WarnLevel, max: zapcore.FatalLevel},
+		{expr: "> info", min: zapcore.WarnLevel, max: zapcore.FatalLevel},
+		{expr: "<warn", min: zapcore.DebugLevel, max: zapcore.InfoLevel},
+		{expr: "<=info", min: zapcore.DebugLevel, max: zapcore.InfoLevel},
+		{expr: "=error", min: zapcore.ErrorLevel, max: zapcore.ErrorLevel},
+		{expr: "error", min: zapcore.ErrorLevel, max: zapcore.ErrorLevel},
+		{expr: "<debug", wantErr: true},
+		{expr: ">=verbose", wantErr: true},
+		{expr: "!warn", wantErr: true},
+	}
+	for _, tt := range tests {
+		r, err := parseLevelRange(tt.expr)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseLevelRange(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && (r.min != tt.min || r.max != tt.max) {
+			t.Errorf("parseLevelRange(%q) = %v-%v, want %v-%v", tt.expr, r.min, r.max, tt.min, tt.max)
+		}
+	}
+}
+
+func TestLevelRoutes(t *testing.T) {
+	dir := t.TempDir()
+	appLog, errorLog := filepath.Join(dir, "app.log"), filepath.Join(dir, "errors.log")
+	err := SetupAppLoggerWithOptions("prod", "", false, WithLevelRoutes(
+		LevelRoute{Outputs: []string{appLog}},
+		LevelRoute{Levels: ">=warn", Outputs: []string{errorLog}},
+	))
+	if err != nil {
+		t.Fatalf("unexpected error setting up routed logger: %v", err)
+	}
+	L.Debug("Debug message should not appear")
+	S.Info("Info message")
+	L.Error("Error message")
+	SetLevel("debug") // The routes share the global atomic level
+	L.Debug("Debug message should appear")
+	SyncZap()
+	appData, _ := os.ReadFile(appLog)
+	errorData, _ := os.ReadFile(errorLog)
+	for _, msg := range []string{"Info message", "Error message", "Debug message should appear"} {
+		if !strings.Contains(string(appData), msg) {
+			t.Errorf("expected '%v' in app log: %v", msg, string(appData))
+		}
+	}
+	if strings.Contains(string(appData), "should not appear") {
+		t.Errorf("did not expect filtered debug message in app log: %v", string(appData))
+	}
+	if !strings.Contains(string(errorData), "Error message") || strings.Contains(string(errorData), "Info message") {
+		t.Errorf("expected only the error message in the error log: %v", string(errorData))
+	}
+}
+
+func TestLevelRoutesConfigFile(t *testing.T) {
+	if err := ValidateConfigFile("./tests/zap_config-routes.json"); err != nil {
+		t.Errorf("unexpected error validating routes config file: %v", err)
+	}
+	if err := NewSugaredLoggerFromFile("./tests/zap_config-routes.json"); err != nil {
+		t.Fatalf("unexpected error loading routes config file: %v", err)
+	}
+	S.Debug("Debug message to stdout")
+	S.Warn("Warn message to stderr")
+	err := NewLoggerFromConfig(Config{Config: ProdConfig(zapcore.InfoLevel).Config, Routes: []LevelRoute{{Levels: "<debug", Outputs: []string{"stdout"}}}})
+	if err == nil {
+		t.Errorf("expected an error from an invalid level route")
+	}
+	err = NewLoggerFromConfig(Config{Config: ProdConfig(zapcore.InfoLevel).Config, Routes: []LevelRoute{{Levels: "info"}}})
+	if err == nil {
+		t.Errorf("expected an error from a level route without outputs")
+	}
+}
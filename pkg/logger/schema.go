@@ -0,0 +1,310 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported cloud provider log schemas.
+const (
+	SchemaGCP = "gcp" // Google Cloud Logging structured logging
+	SchemaAWS = "aws" // AWS CloudWatch (Lambda Powertools style)
+	SchemaECS = "ecs" // Elastic Common Schema
+
+	ecsVersion = "8.11.0" // ECS version implemented by the ECS schema
+)
+
+// GCPProjectEnvs lists the environment variables checked for the GCP project ID, used to build fully qualified trace names.
+var GCPProjectEnvs = []string{"GOOGLE_CLOUD_PROJECT", "GCP_PROJECT", "GCLOUD_PROJECT"}
+
+// fieldMapper writes a correlation field value in a schema specific way.
+type fieldMapper func(enc zapcore.ObjectEncoder, value string)
+
+// logSchema describes the key names and correlation fields of a cloud provider log schema.
+type logSchema struct {
+	encoderConfig func() zapcore.EncoderConfig
+	initialFields map[string]interface{}
+	fields        map[string]fieldMapper // Remapping of the request/trace/span ID fields
+	identityKeys  map[string]string      // Key names of the service identity fields
+	callerLineKey string                 // Key of the caller's line number, if it's logged separately from the file
+}
+
+var logSchemas = map[string]logSchema{
+	SchemaGCP: {
+		encoderConfig: func() zapcore.EncoderConfig {
+			return zapcore.EncoderConfig{
+				TimeKey:        "time",
+				LevelKey:       "severity",
+				NameKey:        "logger",
+				CallerKey:      "caller",
+				MessageKey:     "message",
+				StacktraceKey:  "stack_trace",
+				LineEnding:     zapcore.DefaultLineEnding,
+				EncodeLevel:    gcpSeverityEncoder,
+				EncodeTime:     zapcore.RFC3339NanoTimeEncoder,
+				EncodeDuration: zapcore.MillisDurationEncoder,
+				EncodeCaller:   zapcore.ShortCallerEncoder,
+			}
+		},
+		fields: map[string]fieldMapper{
+			RequestIDLogKey: func(enc zapcore.ObjectEncoder, value string) {
+				_ = enc.AddObject("logging.googleapis.com/labels", zapcore.ObjectMarshalerFunc(func(labels zapcore.ObjectEncoder) error {
+					labels.AddString("request_id", value)
+					return nil
+				}))
+			},
+			TraceIDLogKey: func(enc zapcore.ObjectEncoder, value string) {
+				enc.AddString("logging.googleapis.com/trace", gcpTraceName(value))
+			},
+			SpanIDLogKey: renameField("logging.googleapis.com/spanId"),
+		},
+	},
+	SchemaAWS: {
+		encoderConfig: func() zapcore.EncoderConfig {
+			return zapcore.EncoderConfig{
+				TimeKey:        "timestamp",
+				LevelKey:       "level",
+				NameKey:        "logger",
+				CallerKey:      "location",
+				MessageKey:     "message",
+				StacktraceKey:  "stack_trace",
+				LineEnding:     zapcore.DefaultLineEnding,
+				EncodeLevel:    zapcore.CapitalLevelEncoder,
+				EncodeTime:     zapcore.ISO8601TimeEncoder,
+				EncodeDuration: zapcore.MillisDurationEncoder,
+				EncodeCaller:   zapcore.ShortCallerEncoder,
+			}
+		},
+		fields: map[string]fieldMapper{
+			RequestIDLogKey: renameField("correlation_id"),
+			TraceIDLogKey: func(enc zapcore.ObjectEncoder, value string) {
+				enc.AddString("xray_trace_id", xrayTraceID(value))
+			},
+			SpanIDLogKey: renameField("span_id"),
+		},
+	},
+	SchemaECS: {
+		encoderConfig: func() zapcore.EncoderConfig {
+			return zapcore.EncoderConfig{
+				TimeKey:        "@timestamp",
+				LevelKey:       "log.level",
+				NameKey:        "log.logger",
+				CallerKey:      "log.origin.file.name",
+				FunctionKey:    "log.origin.function",
+				MessageKey:     "message",
+				StacktraceKey:  "error.stack_trace",
+				LineEnding:     zapcore.DefaultLineEnding,
+				EncodeLevel:    zapcore.LowercaseLevelEncoder,
+				EncodeTime:     zapcore.ISO8601TimeEncoder,
+				EncodeDuration: zapcore.NanosDurationEncoder,
+				EncodeCaller:   callerFileEncoder,
+			}
+		},
+		initialFields: map[string]interface{}{"ecs.version": ecsVersion},
+		callerLineKey: "log.origin.file.line",
+		fields: map[string]fieldMapper{
+			RequestIDLogKey: renameField("http.request.id"),
+			TraceIDLogKey:   renameField("trace.id"),
+			SpanIDLogKey:    renameField("span.id"),
+		},
+		identityKeys: map[string]string{
+			IdentityService:   "service.name",
+			IdentityEnv:       "service.environment",
+			IdentityVersion:   "service.version",
+			IdentityHost:      "host.hostname",
+			IdentityPID:       "process.pid",
+			IdentityPod:       "kubernetes.pod.name",
+			IdentityNamespace: "kubernetes.namespace",
+			IdentityNode:      "kubernetes.node.name",
+		},
+	},
+}
+
+func init() {
+	for name, schema := range logSchemas {
+		err := zap.RegisterEncoder(schemaEncoding(name), func(cfg zapcore.EncoderConfig) (zapcore.Encoder, error) {
+			enc := schemaEncoder{Encoder: zapcore.NewJSONEncoder(cfg), fields: schema.fields}
+			if len(cfg.CallerKey) > 0 {
+				enc.callerLineKey = schema.callerLineKey
+			}
+			return enc, nil
+		})
+		if err != nil {
+			panic(fmt.Sprintf("failed to register %v encoder: %v", schemaEncoding(name), err))
+		}
+	}
+}
+
+// schemaEncoding returns the name of the encoder registered for the given schema.
+func schemaEncoding(name string) string {
+	return "json-" + name
+}
+
+// normaliseSchema returns the canonical schema name, supporting some common aliases.
+func normaliseSchema(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch name {
+	case "google", "stackdriver":
+		return SchemaGCP
+	case "cloudwatch":
+		return SchemaAWS
+	case "elastic", "elasticsearch":
+		return SchemaECS
+	default:
+		return name
+	}
+}
+
+// SchemaEncoderConfig returns the encoder config used by the named schema (gcp, aws or ecs).
+func SchemaEncoderConfig(name string) (zapcore.EncoderConfig, error) {
+	schema, ok := logSchemas[normaliseSchema(name)]
+	if !ok {
+		return zapcore.EncoderConfig{}, fmt.Errorf("unknown log schema '%v' (expected one of: %v, %v, %v)", name, SchemaGCP, SchemaAWS, SchemaECS)
+	}
+	return schema.encoderConfig(), nil
+}
+
+// applySchema switches the config over to the JSON encoder and key names of the configured schema.
+func applySchema(cfg *Config) error {
+	if len(cfg.Schema) == 0 {
+		return nil
+	}
+	name := normaliseSchema(cfg.Schema)
+	encCfg, err := SchemaEncoderConfig(name)
+	if err != nil {
+		return err
+	}
+	cfg.Encoding = schemaEncoding(name)
+	cfg.EncoderConfig = encCfg
+	if extra := logSchemas[name].initialFields; len(extra) > 0 {
+		fields := make(map[string]interface{}, len(cfg.InitialFields)+len(extra))
+		maps.Copy(fields, extra)
+		maps.Copy(fields, cfg.InitialFields) // Don't modify the caller's map & let their fields win
+		cfg.InitialFields = fields
+	}
+	return nil
+}
+
+// schemaEncoder is a JSON encoder which remaps the request/trace/span ID fields to the schema's correlation fields.
+type schemaEncoder struct {
+	zapcore.Encoder
+	fields        map[string]fieldMapper
+	callerLineKey string // Key of the caller's line number, if it's logged separately from the file
+}
+
+// AddString adds a string field, remapping it if it's a correlation field.
+func (e schemaEncoder) AddString(key, value string) {
+	if mapper, ok := e.fields[key]; ok {
+		mapper(e.Encoder, value)
+		return
+	}
+	e.Encoder.AddString(key, value)
+}
+
+// Clone copies the encoder, including any accumulated fields.
+func (e schemaEncoder) Clone() zapcore.Encoder {
+	return schemaEncoder{Encoder: e.Encoder.Clone(), fields: e.fields, callerLineKey: e.callerLineKey}
+}
+
+// EncodeEntry encodes the entry, remapping any correlation fields (and adding the caller's line number if required).
+func (e schemaEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	addLine := len(e.callerLineKey) > 0 && ent.Caller.Defined
+	if len(fields) == 0 && !addLine {
+		return e.Encoder.EncodeEntry(ent, nil)
+	}
+	final := e.Clone().(schemaEncoder)
+	if addLine {
+		final.Encoder.AddInt(e.callerLineKey, ent.Caller.Line)
+	}
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+	return final.Encoder.EncodeEntry(ent, nil)
+}
+
+// renameField returns a mapper which writes the value under a new key.
+func renameField(key string) fieldMapper {
+	return func(enc zapcore.ObjectEncoder, value string) {
+		enc.AddString(key, value)
+	}
+}
+
+// callerFileEncoder serialises a caller as its package/file path, without the line number (see logSchema.callerLineKey).
+func callerFileEncoder(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+	path := caller.TrimmedPath()
+	if i := strings.LastIndexByte(path, ':'); i >= 0 {
+		path = path[:i]
+	}
+	enc.AppendString(path)
+}
+
+// gcpSeverityEncoder serialises a Level to a Google Cloud Logging severity.
+func gcpSeverityEncoder(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch l {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	case zapcore.InvalidLevel:
+		enc.AppendString("DEFAULT")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
+
+// gcpTraceName returns the fully qualified trace name (projects/<project>/traces/<id>) if the project is known.
+func gcpTraceName(traceID string) string {
+	for _, env := range GCPProjectEnvs {
+		if project := os.Getenv(env); len(project) > 0 {
+			return fmt.Sprintf("projects/%v/traces/%v", project, traceID)
+		}
+	}
+	return traceID
+}
+
+// xrayTraceID converts a 32 character W3C/OTel trace ID into the AWS X-Ray format (1-<8 hex>-<24 hex>).
+func xrayTraceID(traceID string) string {
+	if len(traceID) != 32 {
+		return traceID
+	}
+	return fmt.Sprintf("1-%v-%v", traceID[:8], traceID[8:])
+}
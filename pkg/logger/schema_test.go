@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	testTraceID = "0af7651916cd43dd8448eb211c80319c"
+	testSpanID  = "b7ad6b7169203331"
+)
+
+// logSchemaMessage logs a message with correlation fields attached to the context.
+func logSchemaMessage(*zap.Logger) {
+	ctx := WithFields(context.Background(), zap.String(RequestIDLogKey, "req-1"),
+		zap.String(TraceIDLogKey, testTraceID), zap.String(SpanIDLogKey, testSpanID))
+	Ctx(ctx).Warn("Schema message", zap.String("other", "value"))
+}
+
+func TestSchemaGCP(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "my-project")
+	entry := logJSONEntry(t, []Option{WithSchema("gcp")}, logSchemaMessage)
+	expected := map[string]interface{}{
+		"severity":                      "WARNING",
+		"message":                       "Schema message",
+		"logging.googleapis.com/trace":  "projects/my-project/traces/" + testTraceID,
+		"logging.googleapis.com/spanId": testSpanID,
+		"other":                         "value",
+	}
+	checkEntryFields(t, entry, expected)
+	labels, _ := entry["logging.googleapis.com/labels"].(map[string]interface{})
+	if labels["request_id"] != "req-1" {
+		t.Errorf("expected request id label, got: %v", entry)
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Errorf("expected time key, got: %v", entry)
+	}
+}
+
+func TestSchemaAWS(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithSchema("cloudwatch")}, logSchemaMessage)
+	expected := map[string]interface{}{
+		"level":          "WARN",
+		"message":        "Schema message",
+		"correlation_id": "req-1",
+		"xray_trace_id":  "1-0af76519-16cd43dd8448eb211c80319c",
+		"span_id":        testSpanID,
+	}
+	checkEntryFields(t, entry, expected)
+	if _, ok := entry["timestamp"]; !ok {
+		t.Errorf("expected timestamp key, got: %v", entry)
+	}
+}
+
+func TestSchemaECS(t *testing.T) {
+	entry := logJSONEntry(t, []Option{WithSchema("ecs")}, logSchemaMessage)
+	expected := map[string]interface{}{
+		"log.level":            "warn",
+		"message":              "Schema message",
+		"http.request.id":      "req-1",
+		"trace.id":             testTraceID,
+		"span.id":              testSpanID,
+		"ecs.version":          ecsVersion,
+		"log.origin.file.name": "logger/schema_test.go",
+	}
+	checkEntryFields(t, entry, expected)
+	if line, ok := entry["log.origin.file.line"].(float64); !ok || line <= 0 {
+		t.Errorf("expected the caller line number in log.origin.file.line, got: %v", entry)
+	}
+	if _, ok := entry["@timestamp"]; !ok {
+		t.Errorf("expected @timestamp key, got: %v", entry)
+	}
+	if _, ok := entry[RequestIDLogKey]; ok {
+		t.Errorf("did not expect the original request id key, got: %v", entry)
+	}
+}
+
+func TestSchemaConfig(t *testing.T) {
+	if err := SetupAppLoggerWithOptions("prod", "", false, WithSchema("unknown")); err == nil {
+		t.Errorf("expected an error from an unknown schema")
+	}
+	if err := ValidateConfigFile("./tests/zap_config-schema.json"); err != nil {
+		t.Errorf("unexpected error validating schema config file: %v", err)
+	}
+	if err := NewSugaredLoggerFromFile("./tests/zap_config-schema.json"); err != nil {
+		t.Fatalf("unexpected error loading schema config file: %v", err)
+	}
+	S.Info("ECS message from config file")
+	cfg := ProdConfig(zapcore.InfoLevel)
+	cfg.Schema = SchemaECS
+	cfg.InitialFields = map[string]interface{}{"ecs.version": "1.6.0"}
+	if err := applySchema(&cfg); err != nil {
+		t.Fatalf("unexpected error applying schema: %v", err)
+	}
+	if cfg.InitialFields["ecs.version"] != "1.6.0" {
+		t.Errorf("expected the supplied initial fields to take precedence: %v", cfg.InitialFields)
+	}
+}
@@ -0,0 +1,353 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SinkConfig describes an output sink that can be attached to the loggers while the system is running.
+type SinkConfig struct {
+	Name     string   `json:"name"`               // Unique name of the sink
+	Level    string   `json:"level,omitempty"`    // Minimum level to write (default: debug)
+	Encoding string   `json:"encoding,omitempty"` // json (default), console or pretty-console
+	Outputs  []string `json:"outputs"`            // Output destinations (stdout/stderr/file)
+}
+
+// runtimeSink is an attached sink, along with its core and the function to close its outputs.
+type runtimeSink struct {
+	cfg    SinkConfig
+	core   zapcore.Core
+	close  func()
+	mu     sync.RWMutex // Held for reading while writing, so the outputs aren't closed mid-write
+	closed bool         // Whether the sink has been removed (and its outputs closed)
+}
+
+// sinkRegistry holds the runtime sinks. Readers get a lock free snapshot, writers serialise on the mutex.
+type sinkRegistry struct {
+	mu    sync.Mutex
+	sinks atomic.Pointer[[]*runtimeSink]
+}
+
+var runtimeSinks = &sinkRegistry{} // Sinks attached at runtime
+
+// snapshot returns the currently attached sinks.
+func (r *sinkRegistry) snapshot() []*runtimeSink {
+	if sinks := r.sinks.Load(); sinks != nil {
+		return *sinks
+	}
+	return nil
+}
+
+// AddSink attaches a new output sink to all loggers (including existing child loggers), without rebuilding them.
+// The sink has its own level, independent of the global logging level.
+func AddSink(cfg SinkConfig) error {
+	if len(cfg.Name) == 0 {
+		return errors.New("no sink name provided")
+	}
+	if len(cfg.Outputs) == 0 {
+		return fmt.Errorf("no outputs provided for sink '%v'", cfg.Name)
+	}
+	lvl := zapcore.DebugLevel
+	if len(cfg.Level) > 0 {
+		var err error
+		if lvl, err = zapcore.ParseLevel(cfg.Level); err != nil {
+			return fmt.Errorf("invalid level for sink '%v': %v", cfg.Name, err)
+		}
+	}
+	if len(cfg.Encoding) == 0 {
+		cfg.Encoding = "json"
+	}
+	enc, err := newSinkEncoder(cfg.Encoding)
+	if err != nil {
+		return fmt.Errorf("invalid encoding for sink '%v': %v", cfg.Name, err)
+	}
+	runtimeSinks.mu.Lock()
+	defer runtimeSinks.mu.Unlock()
+	current := runtimeSinks.snapshot()
+	for _, s := range current {
+		if s.cfg.Name == cfg.Name {
+			return fmt.Errorf("sink '%v' already exists", cfg.Name)
+		}
+	}
+	ws, closeOutputs, err := zap.Open(cfg.Outputs...)
+	if err != nil {
+		return fmt.Errorf("failed to open outputs for sink '%v': %v", cfg.Name, err)
+	}
+	cfg.Level = lvl.String()
+	sink := &runtimeSink{cfg: cfg, core: zapcore.NewCore(enc, ws, lvl), close: closeOutputs}
+	updated := append(slices.Clone(current), sink)
+	runtimeSinks.sinks.Store(&updated)
+	logMsg(zapcore.InfoLevel, fmt.Sprintf("Added log sink '%v' (%v) writing to %v.", cfg.Name, cfg.Level, cfg.Outputs))
+	return nil
+}
+
+// RemoveSink detaches the named output sink, flushing and closing its outputs once any in-flight writes have finished.
+// Entries checked before the removal, but written afterwards, are dropped.
+func RemoveSink(name string) error {
+	runtimeSinks.mu.Lock()
+	defer runtimeSinks.mu.Unlock()
+	current := runtimeSinks.snapshot()
+	i := slices.IndexFunc(current, func(s *runtimeSink) bool { return s.cfg.Name == name })
+	if i < 0 {
+		return fmt.Errorf("sink '%v' does not exist", name)
+	}
+	sink := current[i]
+	updated := slices.Delete(slices.Clone(current), i, i+1)
+	runtimeSinks.sinks.Store(&updated)
+	sink.mu.Lock() // Wait for any in-flight writes (from an earlier snapshot) to finish
+	sink.closed = true
+	_ = sink.core.Sync()
+	sink.close()
+	sink.mu.Unlock()
+	logMsg(zapcore.InfoLevel, fmt.Sprintf("Removed log sink '%v'.", name))
+	return nil
+}
+
+// Sinks returns the configuration of the currently attached runtime sinks.
+func Sinks() []SinkConfig {
+	current := runtimeSinks.snapshot()
+	configs := make([]SinkConfig, 0, len(current))
+	for _, s := range current {
+		configs = append(configs, s.cfg)
+	}
+	return configs
+}
+
+// newSinkEncoder creates an encoder for a runtime sink.
+func newSinkEncoder(encoding string) (zapcore.Encoder, error) {
+	switch encoding {
+	case "json":
+		return zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), nil
+	case "console":
+		return zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), nil
+	case PrettyConsoleEncoding:
+		cfg := zap.NewDevelopmentEncoderConfig()
+		cfg.EncodeLevel = alignedColorLevelEncoder
+		cfg.EncodeCaller = alignedCallerEncoder
+		return newPrettyConsoleEncoder(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported encoding '%v'", encoding)
+	}
+}
+
+// sinkCore wraps the main logger core, teeing entries to any runtime sinks and alert hooks.
+// Fields added to child loggers are remembered, so that they can be applied to sinks attached later.
+type sinkCore struct {
+	zapcore.Core
+	fields []zapcore.Field
+	cache  atomic.Pointer[sinkWriters] // Runtime sink writers with the fields applied
+}
+
+// sinkWriters holds the runtime sink writers of a sinkCore, for a version of the sink registry.
+type sinkWriters struct {
+	sinks   *[]*runtimeSink
+	writers []*sinkWriter
+}
+
+// newSinkCore wraps the given core with support for runtime sinks.
+func newSinkCore(core zapcore.Core) zapcore.Core {
+	return &sinkCore{Core: core}
+}
+
+// Enabled reports whether the main core, any of the runtime sinks or alert hooks are enabled for the given level.
+func (c *sinkCore) Enabled(lvl zapcore.Level) bool {
+	if c.Core.Enabled(lvl) || alertHooks.enabled(lvl) {
+		return true
+	}
+	for _, s := range runtimeSinks.snapshot() {
+		if s.core.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+// With adds structured context to the main core and remembers it for the runtime sinks.
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	return &sinkCore{
+		Core:   c.Core.With(fields),
+		fields: append(slices.Clip(c.fields), fields...),
+	}
+}
+
+// Check adds the main core, any enabled runtime sinks and alert hooks to the checked entry.
+func (c *sinkCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	ce = c.Core.Check(ent, ce)
+	for _, w := range c.sinkWriters() {
+		if w.sink.core.Enabled(ent.Level) {
+			ce = ce.AddCore(ent, w)
+		}
+	}
+	if alertHooks.enabled(ent.Level) {
+		ce = ce.AddCore(ent, &alertCore{fields: c.fields})
+	}
+	return ce
+}
+
+// sinkWriters returns the writers of the attached runtime sinks, with the core's fields applied.
+// They are cached until the sinks change, rather than applying the fields for every entry.
+func (c *sinkCore) sinkWriters() []*sinkWriter {
+	current := runtimeSinks.sinks.Load()
+	if current == nil {
+		return nil
+	}
+	if cached := c.cache.Load(); cached != nil && cached.sinks == current {
+		return cached.writers
+	}
+	writers := make([]*sinkWriter, 0, len(*current))
+	for _, s := range *current {
+		core := s.core
+		if len(c.fields) > 0 {
+			core = core.With(c.fields)
+		}
+		writers = append(writers, &sinkWriter{Core: core, sink: s})
+	}
+	c.cache.Store(&sinkWriters{sinks: current, writers: writers})
+	return writers
+}
+
+// Sync flushes the main core and all runtime sinks.
+func (c *sinkCore) Sync() error {
+	err := c.Core.Sync()
+	for _, s := range runtimeSinks.snapshot() {
+		err = multierr.Append(err, s.sync())
+	}
+	return err
+}
+
+// sync flushes the sink's outputs, unless they have been closed.
+func (s *runtimeSink) sync() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return nil
+	}
+	return s.core.Sync()
+}
+
+// sinkWriter writes checked entries to a runtime sink (with the logger's fields applied), unless it has been removed.
+type sinkWriter struct {
+	zapcore.Core
+	sink *runtimeSink
+}
+
+// Write writes the entry to the sink, holding its lock so that the outputs can't be closed mid-write.
+func (w *sinkWriter) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	w.sink.mu.RLock()
+	defer w.sink.mu.RUnlock()
+	if w.sink.closed {
+		return nil
+	}
+	return w.Core.Write(ent, fields)
+}
+
+// Sync flushes the sink's outputs, unless they have been closed.
+func (w *sinkWriter) Sync() error {
+	return w.sink.sync()
+}
+
+// newSinksHandler returns a handler which lists (GET), adds (POST) or removes (DELETE ?name=) runtime sinks over HTTP.
+// Added sinks can only write to stdout/stderr, or to files within sinkDir (if supplied).
+func newSinksHandler(sinkDir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(Sinks()); err != nil {
+				logMsg(zapcore.WarnLevel, fmt.Sprintf("Failed to write sinks: %v", err))
+			}
+		case http.MethodPost:
+			var cfg SinkConfig
+			if err := json.NewDecoder(r.Body).Decode(&cfg); err != nil {
+				http.Error(w, fmt.Sprintf("Invalid sink config: %v", err), http.StatusBadRequest)
+				return
+			}
+			outputs, err := resolveSinkOutputs(cfg.Outputs, sinkDir)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			cfg.Outputs = outputs
+			if err = AddSink(cfg); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodDelete:
+			if err := RemoveSink(r.URL.Query().Get("name")); err != nil {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, fmt.Sprintf("Method %v is not supported", r.Method), http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// resolveSinkOutputs checks that the outputs of a sink added over HTTP are stdout/stderr, or files within sinkDir,
+// returning them with relative file paths resolved against sinkDir.
+func resolveSinkOutputs(outputs []string, sinkDir string) ([]string, error) {
+	resolved := make([]string, 0, len(outputs))
+	for _, out := range outputs {
+		if out == "stdout" || out == "stderr" {
+			resolved = append(resolved, out)
+			continue
+		}
+		if len(sinkDir) == 0 {
+			return nil, fmt.Errorf("output '%v' is not allowed: only stdout/stderr are supported", out)
+		}
+		if strings.Contains(out, ":") { // i.e. a URL (file:///etc/passwd) which zap would open as-is
+			return nil, fmt.Errorf("output '%v' is not allowed: only file paths are supported", out)
+		}
+		dir, err := filepath.Abs(sinkDir)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sink directory '%v': %v", sinkDir, err)
+		}
+		path := out
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path = filepath.Clean(path)
+		if rel, relErr := filepath.Rel(dir, path); relErr != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("output '%v' is not allowed: it must be within '%v'", out, sinkDir)
+		}
+		resolved = append(resolved, path)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRuntimeSinks(t *testing.T) {
+	if err := NewProdLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	child := L.With(zap.String("component", "child"))
+	debugLog := filepath.Join(t.TempDir(), "debug.log")
+	if err := AddSink(SinkConfig{Name: "debug", Outputs: []string{debugLog}}); err != nil {
+		t.Fatalf("an error '%s' was not expected when adding a sink", err)
+	}
+	if err := AddSink(SinkConfig{Name: "debug", Outputs: []string{debugLog}}); err == nil {
+		t.Errorf("expected an error adding a duplicate sink")
+	}
+	if sinks := Sinks(); len(sinks) != 1 || sinks[0].Level != "debug" || sinks[0].Encoding != "json" {
+		t.Errorf("unexpected sinks: %+v", sinks)
+	}
+	child.Debug("Debug message only in the sink")
+	L.Info("Info message in both")
+	if err := RemoveSink("debug"); err != nil {
+		t.Fatalf("an error '%s' was not expected when removing a sink", err)
+	}
+	if err := RemoveSink("debug"); err == nil {
+		t.Errorf("expected an error removing a missing sink")
+	}
+	child.Debug("Debug message after removal")
+	data, err := os.ReadFile(debugLog)
+	if err != nil {
+		t.Fatalf("failed to read sink output: %v", err)
+	}
+	output := string(data)
+	if !strings.Contains(output, `"msg":"Debug message only in the sink","component":"child"`) {
+		t.Errorf("expected the child logger debug message & fields in the sink output: %v", output)
+	}
+	if !strings.Contains(output, "Info message in both") {
+		t.Errorf("expected the info message in the sink output: %v", output)
+	}
+	if strings.Contains(output, "after removal") {
+		t.Errorf("did not expect messages after the sink was removed: %v", output)
+	}
+}
+
+func TestSinkWritersCached(t *testing.T) {
+	if err := NewProdLogger("stdout"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	core, ok := L.With(zap.String("component", "cached")).Core().(*sinkCore)
+	if !ok {
+		t.Fatalf("expected a sink core, got: %T", L.Core())
+	}
+	if err := AddSink(SinkConfig{Name: "cached", Outputs: []string{filepath.Join(t.TempDir(), "cached.log")}}); err != nil {
+		t.Fatalf("an error '%s' was not expected when adding a sink", err)
+	}
+	writers := core.sinkWriters()
+	if len(writers) != 1 || core.sinkWriters()[0] != writers[0] {
+		t.Errorf("expected the sink writers to be cached, got: %v", writers)
+	}
+	if err := RemoveSink("cached"); err != nil {
+		t.Fatalf("an error '%s' was not expected when removing a sink", err)
+	}
+	if writers = core.sinkWriters(); len(writers) != 0 {
+		t.Errorf("expected the cached writers to be refreshed after removing the sink, got: %v", writers)
+	}
+}
+
+func TestRemoveSinkWhileWriting(t *testing.T) {
+	if err := NewProdLogger("stderr"); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	SetLevel("error") // Only write to the sink
+	defer SetLevel("info")
+	sinkLog := filepath.Join(t.TempDir(), "busy.log")
+	if err := AddSink(SinkConfig{Name: "busy", Outputs: []string{sinkLog}}); err != nil {
+		t.Fatalf("an error '%s' was not expected when adding a sink", err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child := L.With(zap.Int("writer", i))
+			for j := 0; j < 200; j++ {
+				child.Info("Busy sink message")
+			}
+		}()
+	}
+	if err := RemoveSink("busy"); err != nil {
+		t.Fatalf("an error '%s' was not expected when removing a sink", err)
+	}
+	wg.Wait()
+	data, err := os.ReadFile(sinkLog)
+	if err != nil {
+		t.Fatalf("failed to read sink output: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if !json.Valid([]byte(line)) {
+			t.Errorf("expected only complete entries in the sink output, got: %q", line)
+		}
+	}
+}
+
+func TestAddSinkErrors(t *testing.T) {
+	tests := []SinkConfig{
+		{Outputs: []string{"stdout"}},
+		{Name: "no-outputs"},
+		{Name: "bad-level", Level: "verbose", Outputs: []string{"stdout"}},
+		{Name: "bad-encoding", Encoding: "xml", Outputs: []string{"stdout"}},
+		{Name: "bad-output", Outputs: []string{"unknown://sink"}},
+	}
+	for _, cfg := range tests {
+		if err := AddSink(cfg); err == nil {
+			t.Errorf("expected an error adding sink: %+v", cfg)
+		}
+	}
+	if sinks := Sinks(); len(sinks) != 0 {
+		t.Errorf("did not expect any sinks to be added: %+v", sinks)
+	}
+}
+
+func TestSinksHandler(t *testing.T) {
+	if err := NewProdLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	sinksHandler := newSinksHandler("")
+	body := `{"name":"http","level":"warn","encoding":"console","outputs":["stdout"]}`
+	rec := httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodPost, "/log/sinks", strings.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status code adding sink: %v - %v", rec.Code, rec.Body.String())
+	}
+	L.Warn("Warn message to the console sink")
+	rec = httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodGet, "/log/sinks", nil))
+	if !strings.Contains(rec.Body.String(), `"name":"http"`) {
+		t.Errorf("expected sink in list: %v", rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodPost, "/log/sinks", strings.NewReader("{")))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected bad request from invalid JSON, got: %v", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodDelete, "/log/sinks?name=http", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("unexpected status code removing sink: %v - %v", rec.Code, rec.Body.String())
+	}
+	rec = httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodPut, "/log/sinks", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected method not allowed, got: %v", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodPost, "/log/sinks", strings.NewReader(`{"name":"file","outputs":["/tmp/file.log"]}`)))
+	if rec.Code != http.StatusBadRequest || len(Sinks()) != 0 {
+		t.Errorf("expected file outputs to be rejected without a sink directory, got: %v", rec.Code)
+	}
+}
+
+func TestSinksEndpointOptIn(t *testing.T) {
+	rec := httptest.NewRecorder()
+	dynamicLoggingMux(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log/sinks", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("did not expect the sinks endpoint by default, got: %v", rec.Code)
+	}
+	rec = httptest.NewRecorder()
+	dynamicLoggingMux(newSinksHandler("")).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log/sinks", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected the sinks endpoint once enabled, got: %v", rec.Code)
+	}
+	SetupDynamicLoggingWithSinks("", t.TempDir())
+}
+
+func TestSinksHandlerDirectory(t *testing.T) {
+	if err := NewProdLogger(); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	dir := t.TempDir()
+	sinksHandler := newSinksHandler(dir)
+	for _, out := range []string{"../escape.log", "/etc/passwd", dir, "file:///tmp/url.log", "logs/../../escape.log"} {
+		rec := httptest.NewRecorder()
+		body := `{"name":"bad","outputs":["` + out + `"]}`
+		sinksHandler(rec, httptest.NewRequest(http.MethodPost, "/log/sinks", strings.NewReader(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected output '%v' to be rejected, got: %v", out, rec.Code)
+		}
+	}
+	rec := httptest.NewRecorder()
+	sinksHandler(rec, httptest.NewRequest(http.MethodPost, "/log/sinks", strings.NewReader(`{"name":"file","outputs":["debug.log","stderr"]}`)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status code adding sink: %v - %v", rec.Code, rec.Body.String())
+	}
+	defer func() { _ = RemoveSink("file") }()
+	if sinks := Sinks(); len(sinks) != 1 || sinks[0].Outputs[0] != filepath.Join(dir, "debug.log") {
+		t.Errorf("expected the output to be resolved within the sink directory, got: %+v", sinks)
+	}
+}
@@ -0,0 +1,540 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Supported values for each of the named zap encoders. Anything else silently falls back to the default encoder.
+var knownEncoderValues = map[string][]string{
+	"encoderConfig.levelEncoder":    {"capital", "capitalColor", "color", "lowercase"},
+	"encoderConfig.timeEncoder":     {"rfc3339nano", "RFC3339Nano", "rfc3339", "RFC3339", "iso8601", "ISO8601", "millis", "nanos", "epoch"},
+	"encoderConfig.durationEncoder": {"string", "nanos", "ms", "secs"},
+	"encoderConfig.callerEncoder":   {"full", "short"},
+	"encoderConfig.nameEncoder":     {"full"},
+}
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ConfigProblem describes a single issue found in a logging config file.
+type ConfigProblem struct {
+	Path    string // JSON path of the offending field (i.e. encoderConfig.levelEncoder)
+	Line    int    // Line number of the field in the file (1 based)
+	Column  int    // Column number of the field in the file (1 based)
+	Message string // Description of the problem
+}
+
+// String returns a human-readable version of the problem, including its location.
+func (p ConfigProblem) String() string {
+	if len(p.Path) > 0 {
+		return fmt.Sprintf("%d:%d: %v: %v", p.Line, p.Column, p.Path, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %v", p.Line, p.Column, p.Message)
+}
+
+// ConfigValidationError contains all the problems found while validating a logging config file.
+type ConfigValidationError struct {
+	Filename string
+	Problems []ConfigProblem
+}
+
+// Error lists every problem found in the config file.
+func (e *ConfigValidationError) Error() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "invalid logging config file '%v' (%d problem(s))", e.Filename, len(e.Problems))
+	for _, p := range e.Problems {
+		sb.WriteString("\n  ")
+		sb.WriteString(p.String())
+	}
+	return sb.String()
+}
+
+// ValidateConfigFile checks the supplied JSON config file (and any overlays) without creating a logger.
+// It rejects unknown fields, mistyped values, unsupported levels/encoders and output paths that cannot be written to.
+// All problems are returned in a *ConfigValidationError (one per file with problems), rather than just the first one.
+// Environment variable references are expanded before checking values, and the encoder is checked using the merged config.
+func ValidateConfigFile(filename string, overlays ...string) error {
+	merged := make(map[string]interface{})
+	filenames := append([]string{filename}, overlays...)
+	walkers := make([]*configWalker, len(filenames))
+	encoderLayer := 0                // The file which last set the encoding, for reporting encoder problems
+	levelLayer := len(filenames) - 1 // The file which last set (or removed) the level, for reporting a missing level
+	decoded := true                  // The encoder & level can only be checked if every file could be decoded
+	for i, name := range filenames {
+		if name == "" {
+			return fmt.Errorf("no logging config filename provided")
+		}
+		byteArray, err := os.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("failed to read logging config file '%v': %v", name, err)
+		}
+		layer, w := validateConfigLayer(byteArray)
+		walkers[i] = w
+		if layer == nil {
+			decoded = false
+		} else {
+			mergeConfigLayer(merged, layer, true)
+			if _, found := layer["encoding"]; found {
+				encoderLayer = i
+			} else if _, found = layer["encoderConfig"]; found {
+				encoderLayer = i
+			}
+			if _, found := layer["level"]; found {
+				levelLayer = i
+			}
+		}
+	}
+	var cfg zap.Config
+	if data, err := json.Marshal(merged); decoded && err == nil && json.Unmarshal(data, &cfg) == nil {
+		walkers[encoderLayer].checkEncoder(cfg)
+		if cfg.Level == (zap.AtomicLevel{}) {
+			walkers[levelLayer].addProblem("level", walkers[levelLayer].offsets["level"], "missing level")
+		}
+	}
+	var errs []error
+	for i, w := range walkers {
+		if problems := w.sortedProblems(); len(problems) > 0 {
+			errs = append(errs, &ConfigValidationError{Filename: filenames[i], Problems: problems})
+		}
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
+
+// validateConfigLayer runs the structural and semantic checks over a single JSON config file,
+// returning the decoded (and expanded) layer, if it could be decoded.
+func validateConfigLayer(data []byte) (map[string]interface{}, *configWalker) {
+	w := newConfigWalker(data)
+	if err := w.walk(reflect.TypeOf(Config{})); err != nil {
+		w.problems = append(w.problems, w.problemAt("", w.syntaxOffset(err), fmt.Sprintf("invalid JSON: %v", err)))
+		return nil, w
+	}
+	w.checkValues()
+	layer, err := decodeConfigLayer(data)
+	if err != nil {
+		w.addProblem("", w.syntaxOffset(err), err.Error())
+		return nil, w
+	}
+	expanded, err := json.Marshal(layer)
+	if err == nil {
+		var cfg zap.Config
+		err = json.Unmarshal(expanded, &cfg)
+	}
+	if err != nil {
+		if len(w.problems) == 0 { // Only report decoding issues not already covered by the other checks
+			w.addProblem("", w.fieldOffset(err), err.Error())
+		}
+		return nil, w
+	}
+	return layer, w
+}
+
+// configWalker streams through a JSON config, matching every key against the expected Go types.
+type configWalker struct {
+	data      []byte
+	dec       *json.Decoder
+	problems  []ConfigProblem
+	offsets   map[string]int64  // Start offset of each JSON path
+	values    map[string]string // String values of each JSON path
+	pathOrder []string          // JSON paths in the order they appear in the file
+}
+
+// newConfigWalker creates a new walker for the given JSON data.
+func newConfigWalker(data []byte) *configWalker {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return &configWalker{
+		data:    data,
+		dec:     dec,
+		offsets: make(map[string]int64),
+		values:  make(map[string]string),
+	}
+}
+
+// walk validates the whole document against the given type.
+func (w *configWalker) walk(t reflect.Type) error {
+	if err := w.walkValue("", t); err != nil {
+		return err
+	}
+	if _, err := w.dec.Token(); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = fmt.Errorf("unexpected data after top-level value")
+		}
+		return err
+	}
+	return nil
+}
+
+// walkValue validates the next JSON value against the given type. A nil type accepts anything.
+func (w *configWalker) walkValue(path string, t reflect.Type) error {
+	start := w.nextOffset()
+	tok, err := w.dec.Token()
+	if err != nil {
+		return err
+	}
+	w.offsets[path] = start
+	w.pathOrder = append(w.pathOrder, path)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t != nil && isLeafType(t) {
+		t = nil // Custom unmarshalers decide for themselves what they accept
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		switch v {
+		case '{':
+			return w.walkObject(path, start, t)
+		case '[':
+			return w.walkArray(path, start, t)
+		default:
+			return fmt.Errorf("unexpected delimiter '%v'", v)
+		}
+	case string:
+		w.values[path] = expandEnv(v)
+		w.checkKind(path, start, t, "string")
+	case bool:
+		w.checkKind(path, start, t, "bool")
+	case json.Number:
+		w.checkKind(path, start, t, "number")
+	case nil: // null is acceptable for everything
+	}
+	return nil
+}
+
+// walkObject validates the members of a JSON object against a struct or map type.
+func (w *configWalker) walkObject(path string, start int64, t reflect.Type) error {
+	var fields map[string]reflect.Type
+	var elem reflect.Type
+	anyKey := t == nil || t.Kind() == reflect.Interface
+	switch {
+	case anyKey:
+	case t.Kind() == reflect.Struct:
+		fields = jsonFields(t)
+	case t.Kind() == reflect.Map:
+		anyKey = true
+		elem = t.Elem()
+	default:
+		w.addProblem(path, start, fmt.Sprintf("expected %v, got object", kindName(t)))
+		anyKey = true
+	}
+	for w.dec.More() {
+		keyStart := w.nextOffset()
+		tok, err := w.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		childType := elem
+		name := key
+		if !anyKey {
+			var found bool
+			name, childType, found = lookupField(fields, key)
+			if !found {
+				w.addProblem(joinPath(path, key), keyStart, "unknown field")
+			}
+		}
+		if err = w.walkValue(joinPath(path, name), childType); err != nil {
+			return err
+		}
+		w.offsets[joinPath(path, name)] = keyStart // Report the location of the key, rather than the value
+	}
+	_, err := w.dec.Token() // Consume the closing '}'
+	return err
+}
+
+// walkArray validates the elements of a JSON array against a slice type.
+func (w *configWalker) walkArray(path string, start int64, t reflect.Type) error {
+	var elem reflect.Type
+	if t != nil && t.Kind() != reflect.Interface {
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			elem = t.Elem()
+		} else {
+			w.addProblem(path, start, fmt.Sprintf("expected %v, got array", kindName(t)))
+		}
+	}
+	for i := 0; w.dec.More(); i++ {
+		if err := w.walkValue(fmt.Sprintf("%v[%d]", path, i), elem); err != nil {
+			return err
+		}
+	}
+	_, err := w.dec.Token() // Consume the closing ']'
+	return err
+}
+
+// checkKind records a problem if a scalar JSON value (string, bool or number) doesn't fit the expected type.
+func (w *configWalker) checkKind(path string, start int64, t reflect.Type, got string) {
+	if t == nil || t.Kind() == reflect.Interface {
+		return
+	}
+	if want := kindName(t); want != got {
+		w.addProblem(path, start, fmt.Sprintf("expected %v, got %v", want, got))
+	}
+}
+
+// checkValues validates the values of the level, encoder names and output paths.
+func (w *configWalker) checkValues() {
+	for _, path := range w.pathOrder {
+		value, ok := w.values[path]
+		if !ok {
+			continue
+		}
+		switch {
+		case path == "level":
+			if _, err := zapcore.ParseLevel(value); err != nil {
+				w.addProblem(path, w.offsets[path], err.Error())
+			}
+		case isOutputPath(path):
+			if err := checkOutputPath(value); err != nil {
+				w.addProblem(path, w.offsets[path], err.Error())
+			}
+		case path == "schema":
+			if _, err := SchemaEncoderConfig(value); err != nil {
+				w.addProblem(path, w.offsets[path], err.Error())
+			}
+		case strings.HasPrefix(path, "identity.fields["):
+			if !slices.Contains(identityFieldNames, value) {
+				w.addProblem(path, w.offsets[path], fmt.Sprintf("unknown identity field '%v' (expected one of: %v)", value, strings.Join(identityFieldNames, ", ")))
+			}
+		case strings.HasPrefix(path, "routes[") && strings.HasSuffix(path, ".levels"):
+			if _, err := parseLevelRange(value); err != nil {
+				w.addProblem(path, w.offsets[path], err.Error())
+			}
+		default:
+			if allowed, found := knownEncoderValues[path]; found && !slices.Contains(allowed, value) {
+				w.addProblem(path, w.offsets[path], fmt.Sprintf("unknown encoder '%v' (expected one of: %v)", value, strings.Join(allowed, ", ")))
+			}
+		}
+	}
+}
+
+// checkEncoder makes sure the requested encoding is registered and can be created from the encoder config.
+func (w *configWalker) checkEncoder(cfg zap.Config) {
+	encCfg := zap.Config{
+		Level:         zap.NewAtomicLevel(),
+		Encoding:      cfg.Encoding,
+		EncoderConfig: cfg.EncoderConfig,
+	}
+	if _, err := encCfg.Build(); err != nil {
+		w.addProblem("encoding", w.offsets["encoding"], err.Error())
+	}
+}
+
+// sortedProblems returns the problems found, in the order they appear in the file.
+func (w *configWalker) sortedProblems() []ConfigProblem {
+	slices.SortStableFunc(w.problems, func(a, b ConfigProblem) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return w.problems
+}
+
+// addProblem records a problem for the given path, at the supplied file offset.
+func (w *configWalker) addProblem(path string, offset int64, msg string) {
+	w.problems = append(w.problems, w.problemAt(path, offset, msg))
+}
+
+// problemAt creates a problem, converting the file offset into a line & column.
+func (w *configWalker) problemAt(path string, offset int64, msg string) ConfigProblem {
+	if offset > int64(len(w.data)) {
+		offset = int64(len(w.data))
+	}
+	before := w.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := int(offset) - bytes.LastIndexByte(before, '\n')
+	return ConfigProblem{Path: path, Line: line, Column: column, Message: msg}
+}
+
+// nextOffset returns the file offset of the next JSON token (skipping whitespace and separators).
+func (w *configWalker) nextOffset() int64 {
+	offset := w.dec.InputOffset()
+	for offset < int64(len(w.data)) {
+		switch w.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// syntaxOffset extracts the file offset from a JSON decoding error, if available.
+func (w *configWalker) syntaxOffset(err error) int64 {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Offset
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return typeErr.Offset
+	}
+	return w.dec.InputOffset()
+}
+
+// fieldOffset returns the file offset of the field a decoding error refers to, if available.
+func (w *configWalker) fieldOffset(err error) int64 {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		if offset, ok := w.offsets[typeErr.Field]; ok {
+			return offset
+		}
+	}
+	return 0
+}
+
+// checkOutputPath makes sure an output path is either a standard stream, a custom sink or a writable file.
+func checkOutputPath(path string) error {
+	if path == "stdout" || path == "stderr" {
+		return nil
+	}
+	filename := path
+	if u, err := url.Parse(path); err == nil && len(u.Scheme) > 1 { // Ignore single letter (windows drive) schemes
+		if u.Scheme != "file" {
+			return nil // Custom sink, which can only be checked when opened
+		}
+		filename = u.Path
+	}
+	if fi, err := os.Stat(filename); err == nil {
+		if fi.IsDir() {
+			return fmt.Errorf("'%v' is a directory", filename)
+		}
+		f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+		if err != nil {
+			return fmt.Errorf("'%v' is not writable: %v", filename, err)
+		}
+		return f.Close()
+	}
+	dir := filepath.Dir(filename)
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("directory for '%v' does not exist: %v", filename, err)
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("'%v' is not a directory", dir)
+	}
+	f, err := os.CreateTemp(dir, ".zap-validate-*")
+	if err != nil {
+		return fmt.Errorf("directory '%v' is not writable: %v", dir, err)
+	}
+	_ = f.Close()
+	return os.Remove(f.Name())
+}
+
+// isOutputPath reports whether the JSON path refers to an output destination.
+func isOutputPath(path string) bool {
+	return strings.HasPrefix(path, "outputPaths[") || strings.HasPrefix(path, "errorOutputPaths[") ||
+		(strings.HasPrefix(path, "routes[") && strings.Contains(path, ".outputs["))
+}
+
+// jsonFields returns the JSON field names of a struct (including embedded structs) and their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && len(name) == 0 && f.Type.Kind() == reflect.Struct {
+			for k, v := range jsonFields(f.Type) {
+				fields[k] = v
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if len(name) == 0 {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// lookupField finds the named field, using the same case-insensitive fallback as encoding/json.
+func lookupField(fields map[string]reflect.Type, key string) (string, reflect.Type, bool) {
+	if t, ok := fields[key]; ok {
+		return key, t, true
+	}
+	for name, t := range fields {
+		if strings.EqualFold(name, key) {
+			return name, t, true
+		}
+	}
+	return key, nil, false
+}
+
+// isLeafType reports whether the type decodes itself from JSON (i.e. zap levels & encoders).
+func isLeafType(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType)
+}
+
+// kindName returns a JSON friendly name for the expected type.
+func kindName(t reflect.Type) string {
+	kind := t.Kind()
+	if kind == reflect.Struct || kind == reflect.Map {
+		return "object"
+	}
+	if kind == reflect.Slice || kind == reflect.Array {
+		return "array"
+	}
+	if kind >= reflect.Int && kind <= reflect.Float64 {
+		return "number"
+	}
+	return kind.String()
+}
+
+// joinPath appends a key to a JSON path.
+func joinPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: MIT
+/*
+ * Copyright (c) 2026, SCANOSS
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in
+ * all copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+ * THE SOFTWARE.
+ */
+
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateConfigFile(t *testing.T) {
+	if err := ValidateConfigFile(""); err == nil {
+		t.Errorf("expected to get an error from unsupplied config file")
+	}
+	if err := ValidateConfigFile("./tests/does-not-exist.json"); err == nil {
+		t.Errorf("expected to get an error from non-existent config file")
+	}
+	if err := ValidateConfigFile("./tests/zap_config.json"); err != nil {
+		t.Errorf("an error '%v' was not expected when validating a valid config", err)
+	}
+	err := ValidateConfigFile("./tests/zap_config-broken.json")
+	var validationErr *ConfigValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error from a broken config file, got: %v", err)
+	}
+	fmt.Printf("Got expected error message: %v\n", err)
+	if len(validationErr.Problems) != 1 || validationErr.Problems[0].Line != 9 {
+		t.Errorf("expected a single syntax problem on line 9, got: %+v", validationErr.Problems)
+	}
+}
+
+func TestValidateConfigFileProblems(t *testing.T) {
+	err := ValidateConfigFile("./tests/zap_config-invalid.json")
+	var validationErr *ConfigValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a validation error from an invalid config file, got: %v", err)
+	}
+	fmt.Printf("Got expected error message: %v\n", err)
+	expected := map[string]int{ // path -> line
+		"development":               4,
+		"encoderConfig.levelEncodr": 10,
+		"level":                     2,
+		"outputPaths[1]":            5,
+		"encoderConfig.timeEncoder": 11,
+	}
+	if len(validationErr.Problems) != len(expected) {
+		t.Errorf("expected %d problems, got %d: %+v", len(expected), len(validationErr.Problems), validationErr.Problems)
+	}
+	for _, p := range validationErr.Problems {
+		line, ok := expected[p.Path]
+		if !ok {
+			t.Errorf("unexpected problem reported: %v", p)
+		} else if line != p.Line {
+			t.Errorf("expected problem '%v' on line %d, got %d", p.Path, line, p.Line)
+		}
+	}
+}
+
+func TestMissingLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    []string
+		filename string
+		line     int
+	}{
+		{name: "no level", files: []string{"./tests/zap_config-nolevel.json"}, filename: "./tests/zap_config-nolevel.json", line: 1},
+		{name: "null overlay", files: []string{"./tests/zap_config-base.json", "./tests/zap_config-nulllevel.json"},
+			filename: "./tests/zap_config-nulllevel.json", line: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewLoggerFromFile(tt.files[0], tt.files[1:]...); err == nil || !strings.Contains(err.Error(), "missing Level") {
+				t.Errorf("expected a missing level error, got: %v", err)
+			}
+			err := ValidateConfigFile(tt.files[0], tt.files[1:]...)
+			var validationErr *ConfigValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error, got: %v", err)
+			}
+			if validationErr.Filename != tt.filename || len(validationErr.Problems) != 1 {
+				t.Fatalf("expected a single problem in %v, got: %v", tt.filename, err)
+			}
+			if p := validationErr.Problems[0]; p.Path != "level" || p.Line != tt.line || p.Message != "missing level" {
+				t.Errorf("unexpected missing level problem: %+v", p)
+			}
+		})
+	}
+}
+
+func TestCheckOutputPath(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "stdout"},
+		{path: "stderr"},
+		{path: filepath.Join(dir, "app.log")},
+		{path: "file://" + filepath.Join(dir, "app.log")},
+		{path: "custom://sink"},
+		{path: dir, wantErr: true},
+		{path: filepath.Join(dir, "missing", "app.log"), wantErr: true},
+	}
+	for _, tt := range tests {
+		if err := checkOutputPath(tt.path); (err != nil) != tt.wantErr {
+			t.Errorf("checkOutputPath(%v) error = %v, wantErr %v", tt.path, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSetupAppLoggerStrict(t *testing.T) {
+	err := SetupAppLoggerWithOptions("prod", "./tests/zap_config-invalid.json", false, WithStrictConfig())
+	if err == nil {
+		t.Errorf("expected strict mode to reject an invalid config file")
+	}
+	err = SetupAppLoggerWithOptions("prod", "./tests/zap_config.json", false, WithStrictConfig())
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+	Set(nil)
+	err = SetupAppLoggerWithOptions("prod", "./tests/zap_config.json", false, WithDryRun())
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+	if L != nil {
+		t.Errorf("did not expect a dry run to create a logger")
+	}
+	err = SetupAppLoggerWithOptions("dev", "", false, WithOutputs("stdout"), WithStrictConfig())
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+}
@@ -28,20 +28,84 @@
 package logger
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
-var L *zap.Logger                      // Global Logger
-var S *zap.SugaredLogger               // Global Sugared Logger
-var atomicLevel = zap.NewAtomicLevel() // Atomic logging level
+var L *zap.Logger        // Global Logger (kept for compatibility, use Get() for race-free access)
+var S *zap.SugaredLogger // Global Sugared Logger (kept for compatibility, use Sugar() for race-free access)
+
+// loggerState holds a consistent set of global loggers, along with the atomic level controlling them.
+type loggerState struct {
+	logger *zap.Logger
+	sugar  *zap.SugaredLogger
+	level  zap.AtomicLevel
+}
+
+var (
+	state   atomic.Pointer[loggerState] // Current global loggers & level
+	stateMu sync.Mutex                  // Serialises logger swaps (keeping L, S & the zap globals in step)
+)
+
+func init() {
+	state.Store(&loggerState{level: zap.NewAtomicLevel()})
+}
+
+// Get returns the global logger. It is safe to call while the logger is being reconfigured.
+// A no-op logger is returned if no logger has been set up yet.
+func Get() *zap.Logger {
+	if l := state.Load().logger; l != nil {
+		return l
+	}
+	return zap.L()
+}
+
+// Sugar returns the global sugared logger. It is safe to call while the logger is being reconfigured.
+// A no-op logger is returned if no logger has been set up yet.
+func Sugar() *zap.SugaredLogger {
+	if s := state.Load().sugar; s != nil {
+		return s
+	}
+	return zap.S()
+}
+
+// Set replaces the global loggers with the supplied (externally built) logger, or clears them if nil.
+// The current atomic level is kept, but it only controls the new logger if it was built using it.
+func Set(l *zap.Logger) {
+	swapLogger(l, currentLevel())
+}
+
+// currentLevel returns the atomic level controlling the current global logger.
+func currentLevel() zap.AtomicLevel {
+	return state.Load().level
+}
+
+// swapLogger atomically installs the new global loggers & level, also replacing the zap globals,
+// and then flushes the previous logger.
+func swapLogger(l *zap.Logger, level zap.AtomicLevel) {
+	next := &loggerState{logger: l, level: level}
+	globalLogger := zap.NewNop()
+	if l != nil {
+		next.sugar = l.Sugar()
+		globalLogger = l
+	}
+	stateMu.Lock()
+	prev := state.Swap(next)
+	L, S = next.logger, next.sugar
+	zap.ReplaceGlobals(globalLogger)
+	stateMu.Unlock()
+	if prev.logger != nil && prev.logger != l {
+		_ = prev.logger.Sync() // Flush anything written by the previous logger (errors syncing stdout/stderr are expected)
+	}
+}
 
 // NewDevLogger creates a new Development logger.
 func NewDevLogger(outputs ...string) error {
@@ -54,16 +118,9 @@ func NewProdLogger(outputs ...string) error {
 }
 
 // NewDevLoggerLevel creates a Dev logger at the specified logging level.
+// Colored & aligned output is used when writing to a terminal (see NO_COLOR/FORCE_COLOR).
 func NewDevLoggerLevel(lvl zapcore.Level, outputs ...string) error {
-	atomicLevel = zap.NewAtomicLevelAt(lvl)
-	pc := zap.NewDevelopmentConfig()
-	pc.Level = atomicLevel
-	if len(outputs) > 0 {
-		pc.OutputPaths = outputs
-	}
-	var err error
-	L, err = pc.Build()
-	if err != nil {
+	if err := installLogger(DevConfig(lvl, outputs...)); err != nil {
 		return fmt.Errorf("failed to load dev logger: %v", err)
 	}
 	return nil
@@ -71,15 +128,7 @@ func NewDevLoggerLevel(lvl zapcore.Level, outputs ...string) error {
 
 // NewProdLoggerLevel creates a Prod logger at the specified logging level.
 func NewProdLoggerLevel(lvl zapcore.Level, outputs ...string) error {
-	atomicLevel = zap.NewAtomicLevelAt(lvl)
-	pc := zap.NewProductionConfig()
-	pc.Level = atomicLevel
-	if len(outputs) > 0 {
-		pc.OutputPaths = outputs
-	}
-	var err error
-	L, err = pc.Build()
-	if err != nil {
+	if err := installLogger(ProdConfig(lvl, outputs...)); err != nil {
 		return fmt.Errorf("failed to load prod logger: %v", err)
 	}
 	return nil
@@ -87,71 +136,65 @@ func NewProdLoggerLevel(lvl zapcore.Level, outputs ...string) error {
 
 // NewSugaredDevLogger creates a new Development Sugared logger.
 func NewSugaredDevLogger() error {
-	if err := NewDevLogger(); err != nil {
-		return err
-	}
-	S = L.Sugar()
-	return nil
+	return NewDevLogger()
 }
 
 // NewSugaredProdLogger creates a new Production Sugared logger.
 func NewSugaredProdLogger(outputs ...string) error {
-	if err := NewProdLogger(outputs...); err != nil {
-		return err
-	}
-	S = L.Sugar()
-	return nil
+	return NewProdLogger(outputs...)
 }
 
 // NewSugaredProdLoggerLevel creates a new Production Sugared logger at the specified logging level.
 func NewSugaredProdLoggerLevel(lvl zapcore.Level, outputs ...string) error {
-	if err := NewProdLoggerLevel(lvl, outputs...); err != nil {
-		return err
-	}
-	S = L.Sugar()
-	return nil
+	return NewProdLoggerLevel(lvl, outputs...)
 }
 
 // NewLoggerFromFile created a logger from the supplied JSON config file
 // Details for the fields can be found here: https://github.com/uber-go/zap/blob/master/config.go
-func NewLoggerFromFile(filename string) error {
-	if filename == "" {
-		return fmt.Errorf("no logging config filename provided")
-	}
-	byteArray, err := os.ReadFile(filename)
+// Additional fields supported by this package are described in Config.
+// Any overlay files (i.e. logging.prod.json) are merged on top of the first one, in order (see EffectiveConfig).
+func NewLoggerFromFile(filename string, overlays ...string) error {
+	return newLoggerFromFile(append([]string{filename}, overlays...), nil)
+}
+
+// newLoggerFromFile creates a logger from the supplied JSON config files, overridden by any app options.
+func newLoggerFromFile(filenames []string, o *appOptions) error {
+	cfg, err := LoadConfig(filenames...)
 	if err != nil {
-		return fmt.Errorf("failed to read logging config file '%v': %v", filename, err)
-	}
-	var cfg zap.Config
-	if err = json.Unmarshal(byteArray, &cfg); err != nil {
-		return fmt.Errorf("failed to parse logging config json file '%v': %v", filename, err)
+		return err
 	}
-	atomicLevel = cfg.Level // Assign the atomic level parsed from the config file
-	L, err = cfg.Build()
-	if err != nil {
+	o.applyTo(&cfg)
+	prev := state.Load()
+	if err = installLogger(cfg); err != nil {
 		return fmt.Errorf("failed to load prod logger: %v", err)
 	}
+	if prev.logger != nil { // Reloading the config, so record any level change
+		recordLevelChange(prev.level.Level(), currentLevel().Level(), LevelSourceFile, "")
+	}
 	return nil
 }
 
-// NewSugaredLoggerFromFile created a sugared logger from the supplied JSON config file.
-func NewSugaredLoggerFromFile(filename string) error {
-	if err := NewLoggerFromFile(filename); err != nil {
-		return err
-	}
-	S = L.Sugar()
-	return nil
+// NewSugaredLoggerFromFile created a sugared logger from the supplied JSON config file (and any overlays).
+func NewSugaredLoggerFromFile(filename string, overlays ...string) error {
+	return NewLoggerFromFile(filename, overlays...)
 }
 
 // SetLevel enables the setting of the logging level while the system is still running.
 func SetLevel(level string) {
+	SetLevelFrom(level, LevelSourceCode)
+}
+
+// SetLevelFrom sets the logging level, recording the source of the change in the level history.
+func SetLevelFrom(level string, source LevelChangeSource) {
 	if len(level) > 0 {
 		l, err := zapcore.ParseLevel(level)
 		if err != nil {
 			logMsg(zapcore.WarnLevel, fmt.Sprintf("Failed to set level '%v': %v. Ignoring.", level, err))
 		} else {
 			logMsg(zapcore.InfoLevel, fmt.Sprintf("Setting logging level to %v.", l.String()))
-			atomicLevel.SetLevel(l)
+			lvl := currentLevel()
+			recordLevelChange(lvl.Level(), l, source, "")
+			lvl.SetLevel(l)
 		}
 	} else {
 		logMsg(zapcore.WarnLevel, "No level supplied to set")
@@ -160,14 +203,8 @@ func SetLevel(level string) {
 
 // SyncZap flushes the buffered logs and captures any sync issues.
 func SyncZap() {
-	// Sync the Sugared logger if it's set
-	if S != nil {
-		err := S.Sync()
-		if err != nil {
-			fmt.Printf("Warning: Failed to sync zap: %v\n", err)
-		}
-	} else if L != nil { // Otherwise, sync the Logger if it's set
-		err := L.Sync()
+	if l := state.Load().logger; l != nil { // The sugared logger shares the same core, so only sync once
+		err := l.Sync()
 		if err != nil {
 			fmt.Printf("Warning: Failed to sync zap: %v\n", err)
 		}
@@ -177,15 +214,29 @@ func SyncZap() {
 // SetupDynamicLogging enables the ability to modify logging levels on the fly
 // Details on how to call the endpoint can be found here: https://pkg.go.dev/go.uber.org/zap#section-readme
 // To get debug status run: curl -X GET localhost:1065/log/level
-// To set debug status run: curl -X PUT localhost:1065/log/level -d level=debug.
+// To set debug status run: curl -X PUT localhost:1065/log/level -d level=debug
+// To get the level change history run: curl -X GET localhost:1065/log/level/history.
 func SetupDynamicLogging(addr string) {
+	setupDynamicLogging(addr, nil)
+}
+
+// SetupDynamicLoggingWithSinks enables dynamic logging (see SetupDynamicLogging), also exposing the runtime sinks endpoint.
+// Sinks added over HTTP can only write to stdout/stderr, or to files within sinkDir (if supplied).
+// The endpoint is not authenticated, so only listen on an address reachable by trusted clients.
+// To list runtime sinks run: curl -X GET localhost:1065/log/sinks
+// To add a runtime sink run: curl -X POST localhost:1065/log/sinks -d '{"name":"debug","level":"debug","outputs":["debug.log"]}'
+// To remove a runtime sink run: curl -X DELETE localhost:1065/log/sinks?name=debug.
+func SetupDynamicLoggingWithSinks(addr, sinkDir string) {
+	setupDynamicLogging(addr, newSinksHandler(sinkDir))
+}
+
+// setupDynamicLogging serves the dynamic logging endpoints on the given address, including the sinks endpoint if supplied.
+func setupDynamicLogging(addr string, sinks http.HandlerFunc) {
 	if len(addr) > 0 {
-		mux := http.NewServeMux()
-		mux.Handle("/log/level", atomicLevel)
 		server := &http.Server{
 			Addr:              addr,
 			ReadHeaderTimeout: 3 * time.Second,
-			Handler:           mux,
+			Handler:           dynamicLoggingMux(sinks),
 		}
 		go func() {
 			err := server.ListenAndServe()
@@ -198,17 +249,28 @@ func SetupDynamicLogging(addr string) {
 	}
 }
 
+// dynamicLoggingMux returns the dynamic logging endpoints, including the sinks endpoint if supplied.
+func dynamicLoggingMux(sinks http.HandlerFunc) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/log/level", levelHandler)
+	mux.HandleFunc("/log/level/history", levelHistoryHandler)
+	if sinks != nil {
+		mux.HandleFunc("/log/sinks", sinks)
+	}
+	return mux
+}
+
 // logMsg logs the given message to the default logger if available, otherwise standard error.
 func logMsg(level zapcore.Level, msg string) {
 	if len(msg) > 0 {
-		if L != nil {
+		if l := state.Load().logger; l != nil {
 			switch level {
 			case zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel:
-				L.Log(level, msg)
+				l.Log(level, msg)
 			case zapcore.DPanicLevel, zapcore.PanicLevel, zapcore.FatalLevel, zapcore.InvalidLevel:
-				L.Log(level, msg)
+				l.Log(level, msg)
 			default:
-				L.Warn(msg)
+				l.Warn(msg)
 			}
 		} else {
 			message := fmt.Sprintf("%v: %v\n", level.String(), msg)
@@ -219,37 +281,56 @@ func logMsg(level zapcore.Level, msg string) {
 
 // SetupAppLogger creates a zap logger based on the application configuration options.
 func SetupAppLogger(appMode, configFile string, appDebug bool, logOutputs ...string) error {
-	var err error
-	switch strings.ToLower(appMode) {
-	case "prod":
-		if len(configFile) > 0 {
-			err = NewSugaredLoggerFromFile(configFile)
-		} else {
-			err = NewSugaredProdLogger(logOutputs...)
+	return SetupAppLoggerWithOptions(appMode, configFile, appDebug, WithOutputs(logOutputs...))
+}
+
+// SetupAppLoggerWithOptions creates a zap logger based on the application configuration and the supplied options.
+func SetupAppLoggerWithOptions(appMode, configFile string, appDebug bool, opts ...Option) error {
+	o := newAppOptions(opts)
+	if o.strict && len(configFile) > 0 {
+		if err := ValidateConfigFile(configFile, o.overlays...); err != nil {
+			return fmt.Errorf("failed to load logger: %w", err)
 		}
-	default:
-		if len(configFile) > 0 {
-			err = NewSugaredLoggerFromFile(configFile)
+	}
+	if o.dryRun {
+		return nil
+	}
+	var err error
+	if len(configFile) > 0 {
+		err = newLoggerFromFile(append([]string{configFile}, o.overlays...), &o)
+	} else {
+		var cfg Config
+		if strings.ToLower(appMode) == "prod" {
+			cfg = ProdConfig(zapcore.InfoLevel, o.outputs...)
 		} else {
-			err = NewSugaredDevLogger()
+			cfg = DevConfig(zapcore.DebugLevel, o.outputs...)
 		}
+		o.applyTo(&cfg)
+		err = installLogger(cfg)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to load logger: %v", err)
 	}
+	if len(o.crash) > 0 {
+		if err = SetupCrashOutput(o.crash); err != nil {
+			return err
+		}
+	}
 	if appDebug {
 		SetLevel("debug")
 	}
-	L.Debug("Running with debug enabled")
+	Get().Debug("Running with debug enabled")
 	return nil
 }
 
 // SetupAppDynamicLogging enables dynamic app logging if requested.
 func SetupAppDynamicLogging(dynamicPort string, dynamicLogging bool) {
 	if dynamicLogging && len(dynamicPort) > 0 {
-		S.Infof("Setting up dynamic logging level on %v.", dynamicPort)
+		s := Sugar()
+		s.Infof("Setting up dynamic logging level on %v.", dynamicPort)
 		SetupDynamicLogging(dynamicPort)
-		S.Infof("Use the following to get the current status: curl -X GET %v/log/level", dynamicPort)
-		S.Infof("Use the following to set the current status: curl -X PUT %v/log/level -d level=debug", dynamicPort)
+		s.Infof("Use the following to get the current status: curl -X GET %v/log/level", dynamicPort)
+		s.Infof("Use the following to set the current status: curl -X PUT %v/log/level -d level=debug", dynamicPort)
+		s.Infof("Use the following to get the level change history: curl -X GET %v/log/level/history", dynamicPort)
 	}
 }
@@ -24,7 +24,14 @@
 package logger
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"go.uber.org/zap"
@@ -97,10 +104,14 @@ func TestZapPro(t *testing.T) {
 }
 
 func TestZapLocalLog(t *testing.T) {
-	S = nil
-	L = nil
-	logMsg(zapcore.InfoLevel, "Local Info message")
-	logMsg(zapcore.WarnLevel, "Local Warning message")
+	Set(nil)
+	output := captureStderr(t, func() {
+		logMsg(zapcore.InfoLevel, "Local Info message")
+		logMsg(zapcore.WarnLevel, "Local Warning message")
+	})
+	if !strings.Contains(output, "info: Local Info message") || !strings.Contains(output, "warn: Local Warning message") {
+		t.Errorf("expected the messages on stderr without a logger, got: %q", output)
+	}
 	err := NewDevLogger()
 	if err != nil {
 		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
@@ -113,6 +124,55 @@ func TestZapLocalLog(t *testing.T) {
 	logMsg(zapcore.InvalidLevel, "Local Invalid message")
 }
 
+// captureStderr returns what the given function writes to standard error.
+func captureStderr(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("failed to create pipe: %v", err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+	fn()
+	_ = w.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read stderr: %v", err)
+	}
+	return string(data)
+}
+
+// logJSONEntry logs using a production logger (to a temporary file) with the given options, returning the decoded JSON entry.
+func logJSONEntry(t *testing.T, opts []Option, log func(l *zap.Logger)) map[string]interface{} {
+	t.Helper()
+	logFile := filepath.Join(t.TempDir(), "entry.log")
+	if err := SetupAppLoggerWithOptions("prod", "", false, append([]Option{WithOutputs(logFile)}, opts...)...); err != nil {
+		t.Fatalf("unexpected error setting up logger: %v", err)
+	}
+	log(L)
+	SyncZap()
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	var entry map[string]interface{}
+	if err = json.Unmarshal([]byte(strings.TrimSpace(string(data))), &entry); err != nil {
+		t.Fatalf("failed to parse log entry '%v': %v", string(data), err)
+	}
+	return entry
+}
+
+// checkEntryFields reports each expected field that is missing from, or has a different value in, the log entry.
+func checkEntryFields(t *testing.T, entry, expected map[string]interface{}) {
+	t.Helper()
+	for k, v := range expected {
+		if entry[k] != v {
+			t.Errorf("expected %v=%v, got: %v", k, v, entry[k])
+		}
+	}
+}
+
 func TestZapSetLevel(t *testing.T) {
 	S = nil
 	err := NewProdLogger()
@@ -203,3 +263,76 @@ func TestSetupAppDynamicLogging(t *testing.T) {
 	}
 	SetupAppDynamicLogging(":0", true)
 }
+
+func TestGetSugarSet(t *testing.T) {
+	Set(nil)
+	if Get() == nil || Sugar() == nil {
+		t.Fatalf("expected no-op loggers before setup")
+	}
+	Get().Info("no-op message") // Should not panic without a logger
+	l := zap.NewExample()
+	Set(l)
+	if Get() != l || L != l || zap.L() != l {
+		t.Errorf("expected Set to replace the package & zap global loggers")
+	}
+	if Sugar() != S || zap.S() == nil {
+		t.Errorf("expected Set to replace the sugared loggers")
+	}
+	if err := NewProdLoggerLevel(zap.WarnLevel, filepath.Join(t.TempDir(), "get.log")); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	if Get() == l || Get() != L || zap.L() != L || Sugar() != S {
+		t.Errorf("expected the constructor to swap all the global loggers")
+	}
+	if currentLevel().Level() != zap.WarnLevel {
+		t.Errorf("expected the new logger's level to be active, got %v", currentLevel().Level())
+	}
+}
+
+func TestConcurrentReconfiguration(t *testing.T) {
+	dir := t.TempDir()
+	if err := NewProdLogger(filepath.Join(dir, "concurrent.log")); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			ctx := WithFields(context.Background(), zap.Int("worker", id))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				Get().Info("Concurrent info message")
+				Sugar().Debugf("Concurrent debug message %d", id)
+				Ctx(ctx).Warn("Concurrent ctx message")
+				SetLevel("debug")
+			}
+		}(i)
+	}
+	for i := 0; i < 20; i++ {
+		var err error
+		if i%2 == 0 {
+			err = NewProdLoggerLevel(zap.InfoLevel, filepath.Join(dir, "concurrent.log"))
+		} else {
+			err = NewDevLoggerLevel(zap.DebugLevel, filepath.Join(dir, "concurrent.log"))
+			SetLevel("warn") // Make sure the level always targets the current logger
+		}
+		if err != nil {
+			t.Errorf("an error '%s' was not expected when reconfiguring the logger", err)
+		}
+	}
+	close(done)
+	wg.Wait()
+	if err := NewProdLoggerLevel(zap.ErrorLevel, filepath.Join(dir, "concurrent.log")); err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a prod logger", err)
+	}
+	SetLevel("warn")
+	if Get().Core().Enabled(zap.InfoLevel) || !Get().Core().Enabled(zap.WarnLevel) {
+		t.Errorf("expected SetLevel to target the current logger")
+	}
+}